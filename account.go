@@ -0,0 +1,112 @@
+package ringcentral
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+)
+
+// AccountStatus is the status of an account
+type AccountStatus string
+
+// Account statuses
+const (
+	AccountStatusInitial     AccountStatus = "Initial"
+	AccountStatusConfirmed   AccountStatus = "Confirmed"
+	AccountStatusUnconfirmed AccountStatus = "Unconfirmed"
+	AccountStatusDisabled    AccountStatus = "Disabled"
+)
+
+// AccountDetails see https://developer.ringcentral.com/api-reference/Get-Account-Info
+type AccountDetails struct {
+	ID               string             `json:"id"`
+	URI              string             `json:"uri"`
+	MainNumber       string             `json:"mainNumber"`
+	Operator         ExtensionInfo      `json:"operator"`
+	PartnerID        string             `json:"partnerId"`
+	ServiceInfo      AccountServiceInfo `json:"serviceInfo"`
+	SetupWizardState SetupWizardState   `json:"setupWizardState"`
+	Status           AccountStatus      `json:"status"`
+	StatusInfo       StatusInfo         `json:"statusInfo"`
+	RegionalSettings RegionalSettings   `json:"regionalSettings"`
+}
+
+// AccountServiceInfo is the service plan summary included in AccountDetails
+type AccountServiceInfo struct {
+	URI         string          `json:"uri"`
+	Brand       BrandInfo       `json:"brand"`
+	ServicePlan ServicePlanInfo `json:"servicePlan"`
+	BillingPlan BillingPlanInfo `json:"billingPlan"`
+}
+
+// BrandInfo is the RingCentral brand of an account
+type BrandInfo struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	HomeCountry CountryInfo `json:"homeCountry"`
+}
+
+// ServicePlanInfo is the service plan of an account
+type ServicePlanInfo struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Edition string `json:"edition"`
+}
+
+// BillingPlanInfo is the billing plan of an account
+type BillingPlanInfo struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	DurationUnit       string `json:"durationUnit"`
+	Duration           int    `json:"duration"`
+	Type               string `json:"type"`
+	IncludedPhoneLines int    `json:"includedPhoneLines,omitempty"`
+}
+
+// ServiceInfo see https://developer.ringcentral.com/api-reference/Get-Account-Service-Info
+type ServiceInfo struct {
+	URI               string                        `json:"uri"`
+	Brand             BrandInfo                     `json:"brand"`
+	ContractedCountry CountryInfo                   `json:"contractedCountry"`
+	ServicePlan       ServicePlanInfo               `json:"servicePlan"`
+	TargetServicePlan ServicePlanInfo               `json:"targetServicePlan"`
+	BillingPlan       BillingPlanInfo               `json:"billingPlan"`
+	ServiceFeatures   []ExtensionServiceFeatureInfo `json:"serviceFeatures"`
+	Limits            AccountLimits                 `json:"limits"`
+	Package           PackageInfo                   `json:"package"`
+}
+
+// AccountLimits are the limits of the service plan of an account
+type AccountLimits struct {
+	FreeSoftPhoneLinesPerExtension int `json:"freeSoftPhoneLinesPerExtension"`
+	MeetingSize                    int `json:"meetingSize"`
+	CloudRecordingStorage          int `json:"cloudRecordingStorage"`
+	MaxMonitoredExtensionsPerUser  int `json:"maxMonitoredExtensionsPerUser"`
+	MaxExtensionNumberLength       int `json:"maxExtensionNumberLength"`
+	SiteCodeLength                 int `json:"siteCodeLength"`
+	ShortExtensionNumberLength     int `json:"shortExtensionNumberLength"`
+}
+
+// PackageInfo is the package of the service plan of an account
+type PackageInfo struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+// GetAccount returns the details of the account
+func (a *API) GetAccount(ctx context.Context) (*AccountDetails, error) {
+	var d AccountDetails
+	if _, err := a.Get(ctx, fmt.Sprintf("/restapi/v1.0/account/%s", a.AccountID), nil, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// GetServiceInfo returns the service plan, limits and features of the account
+func (a *API) GetServiceInfo(ctx context.Context) (*ServiceInfo, error) {
+	var s ServiceInfo
+	if _, err := a.Get(ctx, fmt.Sprintf("/restapi/v1.0/account/%s/service-info", a.AccountID), nil, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
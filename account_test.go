@@ -0,0 +1,46 @@
+package ringcentral
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestGetAccount(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~", r.URL.Path)
+		writeJSON(w, `{
+			"id": "400",
+			"mainNumber": "+16505550100",
+			"status": "Confirmed",
+			"serviceInfo": {"brand": {"id": "1210", "name": "RingCentral", "homeCountry": {"isoCode": "US"}}, "servicePlan": {"edition": "Premium"}}
+		}`)
+	})
+
+	acct, err := a.GetAccount(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "400", acct.ID)
+		assert.Equal(t, AccountStatusConfirmed, acct.Status)
+		assert.Equal(t, "US", acct.ServiceInfo.Brand.HomeCountry.IsoCode)
+		assert.Equal(t, "Premium", acct.ServiceInfo.ServicePlan.Edition)
+	}
+}
+
+func TestGetServiceInfo(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/restapi/v1.0/account/~/service-info", r.URL.Path)
+		writeJSON(w, `{
+			"limits": {"maxExtensionNumberLength": 5, "freeSoftPhoneLinesPerExtension": 1},
+			"serviceFeatures": [{"featureName": "Presence", "enabled": true}]
+		}`)
+	})
+
+	info, err := a.GetServiceInfo(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, 5, info.Limits.MaxExtensionNumberLength)
+		assert.Len(t, info.ServiceFeatures, 1)
+	}
+}
@@ -0,0 +1,279 @@
+package ringcentral
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"golang.org/x/net/context"
+)
+
+var (
+	// ErrInvalidContactID is returned when an operation requires a personal contact id,
+	// or is given a nil contact
+	ErrInvalidContactID = errors.New("ringcentral: invalid contact id")
+)
+
+// ContactAvailability is the availability of a personal contact
+type ContactAvailability string
+
+// Contact availabilities
+const (
+	ContactAvailabilityAlive   ContactAvailability = "Alive"
+	ContactAvailabilityDeleted ContactAvailability = "Deleted"
+	ContactAvailabilityPurged  ContactAvailability = "Purged"
+)
+
+// SyncType is the type of an address book synchronization
+type SyncType string
+
+// Sync types
+const (
+	SyncTypeFull        SyncType = "FSync"
+	SyncTypeIncremental SyncType = "ISync"
+)
+
+// PersonalContact is a contact in the address book of an extension
+type PersonalContact struct {
+	ID              int64               `json:"id,omitempty"`
+	URI             string              `json:"uri,omitempty"`
+	Availability    ContactAvailability `json:"availability,omitempty"`
+	FirstName       string              `json:"firstName,omitempty"`
+	LastName        string              `json:"lastName,omitempty"`
+	MiddleName      string              `json:"middleName,omitempty"`
+	NickName        string              `json:"nickName,omitempty"`
+	Company         string              `json:"company,omitempty"`
+	JobTitle        string              `json:"jobTitle,omitempty"`
+	Email           string              `json:"email,omitempty"`
+	Email2          string              `json:"email2,omitempty"`
+	Email3          string              `json:"email3,omitempty"`
+	HomePhone       string              `json:"homePhone,omitempty"`
+	HomePhone2      string              `json:"homePhone2,omitempty"`
+	BusinessPhone   string              `json:"businessPhone,omitempty"`
+	BusinessPhone2  string              `json:"businessPhone2,omitempty"`
+	MobilePhone     string              `json:"mobilePhone,omitempty"`
+	BusinessFax     string              `json:"businessFax,omitempty"`
+	CompanyPhone    string              `json:"companyPhone,omitempty"`
+	AssistantPhone  string              `json:"assistantPhone,omitempty"`
+	CarPhone        string              `json:"carPhone,omitempty"`
+	OtherPhone      string              `json:"otherPhone,omitempty"`
+	OtherFax        string              `json:"otherFax,omitempty"`
+	CallbackPhone   string              `json:"callbackPhone,omitempty"`
+	BusinessAddress *ContactAddressInfo `json:"businessAddress,omitempty"`
+	HomeAddress     *ContactAddressInfo `json:"homeAddress,omitempty"`
+	OtherAddress    *ContactAddressInfo `json:"otherAddress,omitempty"`
+	Birthday        string              `json:"birthday,omitempty"`
+	WebPage         string              `json:"webPage,omitempty"`
+	Notes           string              `json:"notes,omitempty"`
+}
+
+// ContactList is a page of personal contacts
+type ContactList struct {
+	URI        string            `json:"uri"`
+	Records    []PersonalContact `json:"records"`
+	Navigation Navigation        `json:"navigation"`
+	Paging     Paging            `json:"paging"`
+}
+
+// SyncInfo holds the token used for the next incremental address book sync
+type SyncInfo struct {
+	SyncType  SyncType `json:"syncType"`
+	SyncToken string   `json:"syncToken"`
+	SyncTime  string   `json:"syncTime"`
+}
+
+// AddressBookSync is the result of an address book synchronization
+type AddressBookSync struct {
+	URI         string            `json:"uri"`
+	Records     []PersonalContact `json:"records"`
+	SyncInfo    SyncInfo          `json:"syncInfo"`
+	NextPageID  int64             `json:"nextPageId,omitempty"`
+	NextPageURI string            `json:"nextPageUri,omitempty"`
+}
+
+// DirectoryPhoneNumber is a phone number of a company directory entry
+type DirectoryPhoneNumber struct {
+	PhoneNumber string `json:"phoneNumber"`
+	Type        string `json:"type"`
+	UsageType   string `json:"usageType"`
+}
+
+// DirectoryEntry is a single entry of the company directory
+type DirectoryEntry struct {
+	ID              string                 `json:"id"`
+	Type            ExtensionType          `json:"type"`
+	Status          ExtensionStatus        `json:"status"`
+	Name            string                 `json:"name"`
+	FirstName       string                 `json:"firstName"`
+	LastName        string                 `json:"lastName"`
+	Email           string                 `json:"email"`
+	JobTitle        string                 `json:"jobTitle"`
+	ExtensionNumber string                 `json:"extensionNumber"`
+	PhoneNumbers    []DirectoryPhoneNumber `json:"phoneNumbers"`
+	Account         AccountInfo            `json:"account"`
+	Site            SiteInfo               `json:"site"`
+	ProfileImage    ProfileImageInfo       `json:"profileImage"`
+}
+
+// DirectoryEntryList is a page of company directory entries
+type DirectoryEntryList struct {
+	Records []DirectoryEntry `json:"records"`
+	Paging  Paging           `json:"paging"`
+}
+
+// DirectorySearchRequest is the request body for SearchDirectory
+type DirectorySearchRequest struct {
+	SearchString  string             `json:"searchString,omitempty"`
+	SearchFields  []string           `json:"searchFields,omitempty"`
+	ExtensionType ExtensionType      `json:"extensionType,omitempty"`
+	ShowFederated bool               `json:"showFederated,omitempty"`
+	OrderBy       []DirectoryOrderBy `json:"orderBy,omitempty"`
+	Page          int                `json:"page,omitempty"`
+	PerPage       int                `json:"perPage,omitempty"`
+}
+
+// DirectoryOrderBy sorts directory search results
+type DirectoryOrderBy struct {
+	Index     int    `json:"index,omitempty"`
+	FieldName string `json:"fieldName"`
+	Direction string `json:"direction,omitempty"`
+}
+
+// FavoriteContact is a favorite of the current extension. Either ExtensionID
+// or ContactID is set, depending on whether it's a company or personal contact.
+type FavoriteContact struct {
+	ID          int64  `json:"id,omitempty"`
+	ExtensionID string `json:"extensionId,omitempty"`
+	AccountID   string `json:"accountId,omitempty"`
+	ContactID   string `json:"contactId,omitempty"`
+}
+
+// FavoriteContactList is the list of favorites of the current extension
+type FavoriteContactList struct {
+	URI     string            `json:"uri,omitempty"`
+	Records []FavoriteContact `json:"records"`
+}
+
+func (a *API) contactURL(id int64) string {
+	urlStr := a.extensionURL(0, "/address-book/contact")
+	if id != 0 {
+		urlStr += fmt.Sprintf("/%d", id)
+	}
+	return urlStr
+}
+
+// ListContacts returns the personal contacts of the current extension
+func (a *API) ListContacts(ctx context.Context, params url.Values) (*ContactList, error) {
+	var l ContactList
+	if _, err := a.Get(ctx, a.contactURL(0), params, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// GetContact returns the given personal contact
+func (a *API) GetContact(ctx context.Context, id int64) (*PersonalContact, error) {
+	if id == 0 {
+		return nil, ErrInvalidContactID
+	}
+	var c PersonalContact
+	if _, err := a.Get(ctx, a.contactURL(id), nil, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// CreateContact adds a personal contact to the address book of the current extension
+func (a *API) CreateContact(ctx context.Context, c *PersonalContact) (*PersonalContact, error) {
+	var result PersonalContact
+	if _, err := a.Post(ctx, a.contactURL(0), c, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateContact updates the personal contact identified by c.ID
+func (a *API) UpdateContact(ctx context.Context, c *PersonalContact) (*PersonalContact, error) {
+	if c == nil || c.ID == 0 {
+		return nil, ErrInvalidContactID
+	}
+	var result PersonalContact
+	if _, err := a.Put(ctx, a.contactURL(c.ID), c, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteContact deletes the given personal contact
+func (a *API) DeleteContact(ctx context.Context, id int64) error {
+	if id == 0 {
+		return ErrInvalidContactID
+	}
+	_, err := a.Delete(ctx, a.contactURL(id))
+	return err
+}
+
+// SyncAddressBook synchronizes the address book of the current extension. If
+// syncToken is empty a full sync is done, otherwise only the contacts changed
+// since the sync which returned the token are returned. All pages are fetched;
+// use the returned SyncInfo.SyncToken for the next call.
+func (a *API) SyncAddressBook(ctx context.Context, syncToken string) (*AddressBookSync, error) {
+	params := url.Values{}
+	if syncToken == "" {
+		params.Set("syncType", string(SyncTypeFull))
+	} else {
+		params.Set("syncType", string(SyncTypeIncremental))
+		params.Set("syncToken", syncToken)
+	}
+
+	var result AddressBookSync
+	for {
+		var s AddressBookSync
+		if _, err := a.Get(ctx, a.extensionURL(0, "/address-book-sync"), params, &s); err != nil {
+			return nil, err
+		}
+		result.URI = s.URI
+		result.SyncInfo = s.SyncInfo
+		result.Records = append(result.Records, s.Records...)
+		if s.NextPageID == 0 {
+			return &result, nil
+		}
+		params.Set("pageId", fmt.Sprintf("%d", s.NextPageID))
+	}
+}
+
+// ListDirectoryEntries returns a page of the company directory
+func (a *API) ListDirectoryEntries(ctx context.Context, params url.Values) (*DirectoryEntryList, error) {
+	var l DirectoryEntryList
+	if _, err := a.Get(ctx, fmt.Sprintf("/restapi/v1.0/account/%s/directory/entries", a.AccountID), params, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// SearchDirectory searches the company directory
+func (a *API) SearchDirectory(ctx context.Context, req *DirectorySearchRequest) (*DirectoryEntryList, error) {
+	var l DirectoryEntryList
+	if _, err := a.Post(ctx, fmt.Sprintf("/restapi/v1.0/account/%s/directory/entries/search", a.AccountID), req, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// GetFavorites returns the favorite contacts of the current extension
+func (a *API) GetFavorites(ctx context.Context) (*FavoriteContactList, error) {
+	var l FavoriteContactList
+	if _, err := a.Get(ctx, a.extensionURL(0, "/favorite"), nil, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// SetFavorites replaces the favorite contacts of the current extension
+func (a *API) SetFavorites(ctx context.Context, favorites []FavoriteContact) (*FavoriteContactList, error) {
+	var l FavoriteContactList
+	if _, err := a.Put(ctx, a.extensionURL(0, "/favorite"), &FavoriteContactList{Records: favorites}, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
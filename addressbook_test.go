@@ -0,0 +1,115 @@
+package ringcentral
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestContactRequests(t *testing.T) {
+	var requests []string
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodPost, http.MethodPut:
+			var c PersonalContact
+			decodeBody(t, r, &c)
+			assert.Equal(t, "Jane", c.FirstName)
+			assert.Equal(t, "Belmont", c.BusinessAddress.City)
+			writeJSON(w, `{"id": 42, "availability": "Alive", "firstName": "Jane", "businessAddress": {"city": "Belmont"}}`)
+		case http.MethodGet:
+			writeJSON(w, `{"id": 42, "availability": "Alive", "firstName": "Jane", "mobilePhone": "+16505550100"}`)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	ctx := context.Background()
+
+	c, err := a.CreateContact(ctx, &PersonalContact{FirstName: "Jane", BusinessAddress: &ContactAddressInfo{City: "Belmont"}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(42), c.ID)
+		assert.Equal(t, ContactAvailabilityAlive, c.Availability)
+	}
+	c, err = a.GetContact(ctx, 42)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "+16505550100", c.MobilePhone)
+	}
+	_, err = a.UpdateContact(ctx, &PersonalContact{ID: 42, FirstName: "Jane", BusinessAddress: &ContactAddressInfo{City: "Belmont"}})
+	assert.NoError(t, err)
+	assert.NoError(t, a.DeleteContact(ctx, 42))
+
+	// Requests without a contact id aren't sent
+	_, err = a.UpdateContact(ctx, &PersonalContact{FirstName: "Jane"})
+	assert.Equal(t, ErrInvalidContactID, err)
+	_, err = a.UpdateContact(ctx, nil)
+	assert.Equal(t, ErrInvalidContactID, err)
+
+	assert.Equal(t, []string{
+		"POST /restapi/v1.0/account/~/extension/~/address-book/contact",
+		"GET /restapi/v1.0/account/~/extension/~/address-book/contact/42",
+		"PUT /restapi/v1.0/account/~/extension/~/address-book/contact/42",
+		"DELETE /restapi/v1.0/account/~/extension/~/address-book/contact/42",
+	}, requests)
+}
+
+func TestSyncAddressBook(t *testing.T) {
+	var queries []string
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/~/address-book-sync", r.URL.Path)
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("pageId") == "" {
+			writeJSON(w, `{"records": [{"id": 1}], "syncInfo": {"syncType": "ISync", "syncToken": "t-1"}, "nextPageId": 7}`)
+			return
+		}
+		writeJSON(w, `{"records": [{"id": 2, "availability": "Deleted"}], "syncInfo": {"syncType": "ISync", "syncToken": "t-2"}}`)
+	})
+
+	s, err := a.SyncAddressBook(context.Background(), "t-0")
+	if assert.NoError(t, err) {
+		assert.Len(t, s.Records, 2)
+		assert.Equal(t, ContactAvailabilityDeleted, s.Records[1].Availability)
+		assert.Equal(t, "t-2", s.SyncInfo.SyncToken)
+	}
+	assert.Equal(t, []string{
+		"syncToken=t-0&syncType=ISync",
+		"pageId=7&syncToken=t-0&syncType=ISync",
+	}, queries)
+}
+
+func TestSearchDirectory(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/directory/entries/search", r.URL.Path)
+		var body map[string]interface{}
+		decodeBody(t, r, &body)
+		assert.Equal(t, map[string]interface{}{"searchString": "jane", "extensionType": "User"}, body)
+		writeJSON(w, `{"records": [{"id": "400131005", "type": "User", "name": "Jane Doe", "extensionNumber": "101", "phoneNumbers": [{"phoneNumber": "+16505550100", "type": "VoiceFax", "usageType": "DirectNumber"}]}], "paging": {"page": 1}}`)
+	})
+
+	l, err := a.SearchDirectory(context.Background(), &DirectorySearchRequest{SearchString: "jane", ExtensionType: ExtensionTypeUser})
+	if assert.NoError(t, err) && assert.Len(t, l.Records, 1) {
+		assert.Equal(t, "101", l.Records[0].ExtensionNumber)
+		assert.Equal(t, "DirectNumber", l.Records[0].PhoneNumbers[0].UsageType)
+	}
+}
+
+func TestSetFavorites(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/~/favorite", r.URL.Path)
+		var body map[string]interface{}
+		decodeBody(t, r, &body)
+		assert.Equal(t, map[string]interface{}{"records": []interface{}{
+			map[string]interface{}{"extensionId": "400131005"},
+			map[string]interface{}{"contactId": "42"},
+		}}, body)
+		writeJSON(w, `{"records": [{"id": 1, "extensionId": "400131005"}, {"id": 2, "contactId": "42"}]}`)
+	})
+
+	l, err := a.SetFavorites(context.Background(), []FavoriteContact{{ExtensionID: "400131005"}, {ContactID: "42"}})
+	if assert.NoError(t, err) && assert.Len(t, l.Records, 2) {
+		assert.Equal(t, int64(2), l.Records[1].ID)
+	}
+}
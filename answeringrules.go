@@ -0,0 +1,286 @@
+package ringcentral
+
+import (
+	"errors"
+	"net/url"
+
+	"golang.org/x/net/context"
+)
+
+var (
+	// ErrInvalidAnsweringRuleID is returned when an operation requires an answering rule id,
+	// or is given a nil rule
+	ErrInvalidAnsweringRuleID = errors.New("ringcentral: invalid answering rule id")
+)
+
+// AnsweringRuleType is the type of an answering rule
+type AnsweringRuleType string
+
+// Answering rule types
+const (
+	AnsweringRuleTypeBusinessHours AnsweringRuleType = "BusinessHours"
+	AnsweringRuleTypeAfterHours    AnsweringRuleType = "AfterHours"
+	AnsweringRuleTypeCustom        AnsweringRuleType = "Custom"
+)
+
+// Answering rule ids of the predefined rules
+const (
+	AnsweringRuleIDBusinessHours = "business-hours-rule"
+	AnsweringRuleIDAfterHours    = "after-hours-rule"
+)
+
+// CallHandlingAction is what happens with a call matched by an answering rule
+type CallHandlingAction string
+
+// Call handling actions
+const (
+	CallHandlingActionForwardCalls            CallHandlingAction = "ForwardCalls"
+	CallHandlingActionUnconditionalForwarding CallHandlingAction = "UnconditionalForwarding"
+	CallHandlingActionAgentQueue              CallHandlingAction = "AgentQueue"
+	CallHandlingActionTransferToExtension     CallHandlingAction = "TransferToExtension"
+	CallHandlingActionTakeMessagesOnly        CallHandlingAction = "TakeMessagesOnly"
+	CallHandlingActionPlayAnnouncementOnly    CallHandlingAction = "PlayAnnouncementOnly"
+	CallHandlingActionSharedLines             CallHandlingAction = "SharedLines"
+)
+
+// RingingMode is the order in which forwarding numbers are called
+type RingingMode string
+
+// Ringing modes
+const (
+	RingingModeSequentially   RingingMode = "Sequentially"
+	RingingModeSimultaneously RingingMode = "Simultaneously"
+)
+
+// AnsweringRuleInfo see https://developer.ringcentral.com/api-reference/Get-Call-Handling-Rule
+type AnsweringRuleInfo struct {
+	ID                      string                       `json:"id,omitempty"`
+	URI                     string                       `json:"uri,omitempty"`
+	Type                    AnsweringRuleType            `json:"type,omitempty"`
+	Name                    string                       `json:"name,omitempty"`
+	Enabled                 *bool                        `json:"enabled,omitempty"`
+	Schedule                *ScheduleInfo                `json:"schedule,omitempty"`
+	CalledNumbers           []CalledNumberInfo           `json:"calledNumbers,omitempty"`
+	Callers                 []CallersInfo                `json:"callers,omitempty"`
+	CallHandlingAction      CallHandlingAction           `json:"callHandlingAction,omitempty"`
+	Forwarding              *ForwardingInfo              `json:"forwarding,omitempty"`
+	UnconditionalForwarding *UnconditionalForwardingInfo `json:"unconditionalForwarding,omitempty"`
+	Queue                   *QueueInfo                   `json:"queue,omitempty"`
+	Transfer                *TransferredExtensionInfo    `json:"transfer,omitempty"`
+	Voicemail               *VoicemailInfo               `json:"voicemail,omitempty"`
+	Greetings               []GreetingInfo               `json:"greetings,omitempty"`
+	Screening               string                       `json:"screening,omitempty"`
+}
+
+// AnsweringRuleList is a page of answering rules
+type AnsweringRuleList struct {
+	URI        string              `json:"uri"`
+	Records    []AnsweringRuleInfo `json:"records"`
+	Navigation Navigation          `json:"navigation"`
+	Paging     Paging              `json:"paging"`
+}
+
+// ScheduleInfo is the schedule of a custom answering rule. Either WeeklyRanges
+// or Ranges is set; Ref refers to the business hours of the extension.
+type ScheduleInfo struct {
+	WeeklyRanges *WeeklyScheduleInfo `json:"weeklyRanges,omitempty"`
+	Ranges       []RangesInfo        `json:"ranges,omitempty"`
+	Ref          string              `json:"ref,omitempty"`
+}
+
+// Schedule references
+const (
+	ScheduleRefBusinessHours = "BusinessHours"
+	ScheduleRefAfterHours    = "AfterHours"
+)
+
+// WeeklyScheduleInfo holds the time ranges for each day of the week
+type WeeklyScheduleInfo struct {
+	Monday    []TimeInterval `json:"monday,omitempty"`
+	Tuesday   []TimeInterval `json:"tuesday,omitempty"`
+	Wednesday []TimeInterval `json:"wednesday,omitempty"`
+	Thursday  []TimeInterval `json:"thursday,omitempty"`
+	Friday    []TimeInterval `json:"friday,omitempty"`
+	Saturday  []TimeInterval `json:"saturday,omitempty"`
+	Sunday    []TimeInterval `json:"sunday,omitempty"`
+}
+
+// TimeInterval is a range of time within a day, formatted as "hh:mm"
+type TimeInterval struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// RangesInfo is a range of dates and times, formatted as "yyyy-MM-dd'T'hh:mm:ss"
+type RangesInfo struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// CalledNumberInfo is a number of the extension a rule applies to
+type CalledNumberInfo struct {
+	PhoneNumber string `json:"phoneNumber"`
+}
+
+// CallersInfo is a caller a rule applies to
+type CallersInfo struct {
+	CallerID string `json:"callerId,omitempty"`
+	Name     string `json:"name,omitempty"`
+}
+
+// ForwardingInfo describes how calls are forwarded
+type ForwardingInfo struct {
+	NotifyMySoftPhones    *bool                `json:"notifyMySoftPhones,omitempty"`
+	NotifyAdminSoftPhones *bool                `json:"notifyAdminSoftPhones,omitempty"`
+	SoftPhonesRingCount   int                  `json:"softPhonesRingCount,omitempty"`
+	RingingMode           RingingMode          `json:"ringingMode,omitempty"`
+	Rules                 []ForwardingRuleInfo `json:"rules,omitempty"`
+	MobileTimeout         *bool                `json:"mobileTimeout,omitempty"`
+}
+
+// ForwardingRuleInfo is a single step of call forwarding
+type ForwardingRuleInfo struct {
+	Index             int                   `json:"index"`
+	RingCount         int                   `json:"ringCount"`
+	Enabled           *bool                 `json:"enabled,omitempty"`
+	ForwardingNumbers []ForwardingNumberRef `json:"forwardingNumbers"`
+}
+
+// ForwardingNumberRef refers to a forwarding number in a forwarding rule
+type ForwardingNumberRef struct {
+	ID          string `json:"id,omitempty"`
+	URI         string `json:"uri,omitempty"`
+	PhoneNumber string `json:"phoneNumber,omitempty"`
+	Label       string `json:"label,omitempty"`
+	Type        string `json:"type,omitempty"`
+}
+
+// UnconditionalForwardingInfo forwards all calls to a single number
+type UnconditionalForwardingInfo struct {
+	PhoneNumber string `json:"phoneNumber"`
+}
+
+// QueueInfo holds the queue settings of a department answering rule
+type QueueInfo struct {
+	TransferMode                string            `json:"transferMode,omitempty"`
+	FixedOrderAgents            []FixedOrderAgent `json:"fixedOrderAgents,omitempty"`
+	HoldAudioInterruptionMode   string            `json:"holdAudioInterruptionMode,omitempty"`
+	HoldAudioInterruptionPeriod int               `json:"holdAudioInterruptionPeriod,omitempty"`
+	AgentTimeout                int               `json:"agentTimeout,omitempty"`
+	WrapUpTime                  int               `json:"wrapUpTime,omitempty"`
+	HoldTime                    int               `json:"holdTime,omitempty"`
+	MaxCallers                  int               `json:"maxCallers,omitempty"`
+	MaxCallersAction            string            `json:"maxCallersAction,omitempty"`
+}
+
+// FixedOrderAgent is an agent of a queue using the fixed order transfer mode
+type FixedOrderAgent struct {
+	Extension ExtensionRef `json:"extension"`
+	Index     int          `json:"index"`
+}
+
+// TransferredExtensionInfo is the extension calls are transferred to
+type TransferredExtensionInfo struct {
+	Extension ExtensionRef `json:"extension"`
+}
+
+// VoicemailInfo holds the voicemail settings of an answering rule
+type VoicemailInfo struct {
+	Enabled   bool          `json:"enabled"`
+	Recipient RecipientInfo `json:"recipient"`
+}
+
+// RecipientInfo is the extension which receives voicemails
+type RecipientInfo struct {
+	ID  string `json:"id"`
+	URI string `json:"uri,omitempty"`
+}
+
+// GreetingInfo is a greeting played by an answering rule
+type GreetingInfo struct {
+	Type   string   `json:"type"`
+	Preset *URIInfo `json:"preset,omitempty"`
+	Custom *URIInfo `json:"custom,omitempty"`
+}
+
+// URIInfo refers to a resource by id and uri
+type URIInfo struct {
+	ID  string `json:"id"`
+	URI string `json:"uri,omitempty"`
+}
+
+// ExtensionRef refers to an extension. Requests only need the ID; responses
+// usually include the other fields as well.
+type ExtensionRef struct {
+	ID              string `json:"id,omitempty"`
+	URI             string `json:"uri,omitempty"`
+	ExtensionNumber string `json:"extensionNumber,omitempty"`
+	Name            string `json:"name,omitempty"`
+}
+
+func (a *API) answeringRuleURL(ext int64, id string) string {
+	urlStr := a.extensionURL(ext, "/answering-rule")
+	if id != "" {
+		urlStr += "/" + url.PathEscape(id)
+	}
+	return urlStr
+}
+
+// ListAnsweringRules returns the answering rules of the given extension
+func (a *API) ListAnsweringRules(ctx context.Context, ext int64, params url.Values) (*AnsweringRuleList, error) {
+	var l AnsweringRuleList
+	if _, err := a.Get(ctx, a.answeringRuleURL(ext, ""), params, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// GetAnsweringRule returns the given answering rule. Use AnsweringRuleIDBusinessHours
+// or AnsweringRuleIDAfterHours for the predefined rules.
+func (a *API) GetAnsweringRule(ctx context.Context, ext int64, id string) (*AnsweringRuleInfo, error) {
+	if id == "" {
+		return nil, ErrInvalidAnsweringRuleID
+	}
+	var r AnsweringRuleInfo
+	if _, err := a.Get(ctx, a.answeringRuleURL(ext, id), nil, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// CreateAnsweringRule creates a custom answering rule for the given extension
+func (a *API) CreateAnsweringRule(ctx context.Context, ext int64, rule *AnsweringRuleInfo) (*AnsweringRuleInfo, error) {
+	if rule == nil {
+		return nil, ErrInvalidAnsweringRuleID
+	}
+	req := *rule
+	if req.Type == "" {
+		req.Type = AnsweringRuleTypeCustom
+	}
+	var r AnsweringRuleInfo
+	if _, err := a.Post(ctx, a.answeringRuleURL(ext, ""), &req, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// UpdateAnsweringRule updates the answering rule identified by rule.ID
+func (a *API) UpdateAnsweringRule(ctx context.Context, ext int64, rule *AnsweringRuleInfo) (*AnsweringRuleInfo, error) {
+	if rule == nil || rule.ID == "" {
+		return nil, ErrInvalidAnsweringRuleID
+	}
+	var r AnsweringRuleInfo
+	if _, err := a.Put(ctx, a.answeringRuleURL(ext, rule.ID), rule, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// DeleteAnsweringRule deletes the given custom answering rule
+func (a *API) DeleteAnsweringRule(ctx context.Context, ext int64, id string) error {
+	if id == "" {
+		return ErrInvalidAnsweringRuleID
+	}
+	_, err := a.Delete(ctx, a.answeringRuleURL(ext, id))
+	return err
+}
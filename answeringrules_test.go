@@ -0,0 +1,94 @@
+package ringcentral
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestAnsweringRuleDecode(t *testing.T) {
+	data := `{
+		"id": "1234",
+		"type": "Custom",
+		"name": "Holiday",
+		"enabled": true,
+		"schedule": {
+			"ranges": [{"from": "2018-12-25T00:00:00", "to": "2018-12-26T00:00:00"}]
+		},
+		"calledNumbers": [{"phoneNumber": "+16505550100"}],
+		"callers": [{"callerId": "+16505550199", "name": "VIP"}],
+		"callHandlingAction": "UnconditionalForwarding",
+		"unconditionalForwarding": {"phoneNumber": "+16505550111"},
+		"voicemail": {"enabled": true, "recipient": {"id": "400131005"}}
+	}`
+	var r AnsweringRuleInfo
+	if !assert.NoError(t, json.Unmarshal([]byte(data), &r)) {
+		return
+	}
+	assert.Equal(t, AnsweringRuleTypeCustom, r.Type)
+	if assert.NotNil(t, r.Enabled) {
+		assert.True(t, *r.Enabled)
+	}
+	if assert.NotNil(t, r.Schedule) {
+		assert.Len(t, r.Schedule.Ranges, 1)
+	}
+	assert.Equal(t, CallHandlingActionUnconditionalForwarding, r.CallHandlingAction)
+	assert.Equal(t, "+16505550111", r.UnconditionalForwarding.PhoneNumber)
+	assert.Equal(t, "400131005", r.Voicemail.Recipient.ID)
+}
+
+func TestCreateAnsweringRule(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/5/answering-rule", r.URL.Path)
+		var body map[string]interface{}
+		decodeBody(t, r, &body)
+		assert.Equal(t, map[string]interface{}{
+			"type":               "Custom",
+			"name":               "Overflow",
+			"callHandlingAction": "TransferToExtension",
+			"transfer":           map[string]interface{}{"extension": map[string]interface{}{"id": "400131009"}},
+		}, body)
+		writeJSON(w, `{"id": "1234", "type": "Custom", "name": "Overflow", "enabled": true, "callHandlingAction": "TransferToExtension", "transfer": {"extension": {"id": "400131009", "extensionNumber": "200", "name": "Support"}}}`)
+	})
+
+	rule := &AnsweringRuleInfo{
+		Name:               "Overflow",
+		CallHandlingAction: CallHandlingActionTransferToExtension,
+		Transfer:           &TransferredExtensionInfo{Extension: ExtensionRef{ID: "400131009"}},
+	}
+	r, err := a.CreateAnsweringRule(context.Background(), 5, rule)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1234", r.ID)
+		assert.Equal(t, ExtensionRef{ID: "400131009", ExtensionNumber: "200", Name: "Support"}, r.Transfer.Extension)
+	}
+	// The rule of the caller is left unchanged
+	assert.Empty(t, rule.Type)
+
+	_, err = a.CreateAnsweringRule(context.Background(), 5, nil)
+	assert.Equal(t, ErrInvalidAnsweringRuleID, err)
+}
+
+func TestUpdateAnsweringRule(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/~/answering-rule/business-hours-rule", r.URL.Path)
+		var body map[string]interface{}
+		decodeBody(t, r, &body)
+		assert.Equal(t, map[string]interface{}{"id": "business-hours-rule", "enabled": false}, body)
+		writeJSON(w, `{"id": "business-hours-rule", "type": "BusinessHours", "enabled": false}`)
+	})
+
+	no := false
+	r, err := a.UpdateAnsweringRule(context.Background(), 0, &AnsweringRuleInfo{ID: AnsweringRuleIDBusinessHours, Enabled: &no})
+	if assert.NoError(t, err) {
+		assert.Equal(t, AnsweringRuleTypeBusinessHours, r.Type)
+	}
+	_, err = a.UpdateAnsweringRule(context.Background(), 0, &AnsweringRuleInfo{})
+	assert.Equal(t, ErrInvalidAnsweringRuleID, err)
+	_, err = a.UpdateAnsweringRule(context.Background(), 0, nil)
+	assert.Equal(t, ErrInvalidAnsweringRuleID, err)
+}
@@ -0,0 +1,233 @@
+package ringcentral
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// rangesTimeFormat is the layout of the dates in RangesInfo
+const rangesTimeFormat = "2006-01-02T15:04:05"
+
+// BusinessHours see https://developer.ringcentral.com/api-reference/Get-User-Business-Hours
+type BusinessHours struct {
+	URI      string                `json:"uri,omitempty"`
+	Schedule BusinessHoursSchedule `json:"schedule"`
+}
+
+// BusinessHoursSchedule is the weekly schedule of business hours. An empty
+// schedule means the business is open 24/7.
+type BusinessHoursSchedule struct {
+	WeeklyRanges WeeklyScheduleInfo `json:"weeklyRanges"`
+}
+
+// GetAccountBusinessHours returns the company business hours
+func (a *API) GetAccountBusinessHours(ctx context.Context) (*BusinessHours, error) {
+	var b BusinessHours
+	if _, err := a.Get(ctx, fmt.Sprintf("/restapi/v1.0/account/%s/business-hours", a.AccountID), nil, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// UpdateAccountBusinessHours updates the company business hours
+func (a *API) UpdateAccountBusinessHours(ctx context.Context, hours *BusinessHours) (*BusinessHours, error) {
+	var b BusinessHours
+	if _, err := a.Put(ctx, fmt.Sprintf("/restapi/v1.0/account/%s/business-hours", a.AccountID), hours, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// GetBusinessHours returns the business hours of the given extension
+func (a *API) GetBusinessHours(ctx context.Context, ext int64) (*BusinessHours, error) {
+	var b BusinessHours
+	if _, err := a.Get(ctx, a.extensionURL(ext, "/business-hours"), nil, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// UpdateBusinessHours updates the business hours of the given extension
+func (a *API) UpdateBusinessHours(ctx context.Context, ext int64, hours *BusinessHours) (*BusinessHours, error) {
+	var b BusinessHours
+	if _, err := a.Put(ctx, a.extensionURL(ext, "/business-hours"), hours, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// Day returns the time ranges of the given day of the week
+func (w *WeeklyScheduleInfo) Day(day time.Weekday) []TimeInterval {
+	switch day {
+	case time.Monday:
+		return w.Monday
+	case time.Tuesday:
+		return w.Tuesday
+	case time.Wednesday:
+		return w.Wednesday
+	case time.Thursday:
+		return w.Thursday
+	case time.Friday:
+		return w.Friday
+	case time.Saturday:
+		return w.Saturday
+	default:
+		return w.Sunday
+	}
+}
+
+// Empty returns true if no time ranges are set for any day
+func (w *WeeklyScheduleInfo) Empty() bool {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if len(w.Day(d)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains returns true if the clock time of t is within the interval. An
+// interval with To before From spans midnight, and one with To equal to From,
+// such as 00:00 to 00:00, lasts the whole day.
+func (i TimeInterval) Contains(t time.Time) (bool, error) {
+	sameDay, nextDay, err := i.covers(t)
+	return sameDay || nextDay, err
+}
+
+// covers returns whether the clock time of t is within the interval on the
+// day it starts, and on the following day for intervals spanning midnight
+func (i TimeInterval) covers(t time.Time) (sameDay, nextDay bool, err error) {
+	from, to, err := i.minutes()
+	if err != nil {
+		return false, false, err
+	}
+	m := t.Hour()*60 + t.Minute()
+	if to <= from {
+		return m >= from, m < to, nil
+	}
+	return m >= from && m < to, false, nil
+}
+
+func (i TimeInterval) minutes() (from, to int, err error) {
+	if from, err = parseClock(i.From); err != nil {
+		return
+	}
+	to, err = parseClock(i.To)
+	return
+}
+
+// parseClock returns the minutes since midnight of a "hh:mm" string
+func parseClock(s string) (int, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("ringcentral: invalid time %q", s)
+	}
+	h, err := strconv.Atoi(parts[0])
+	if err != nil || h < 0 || h > 24 {
+		return 0, fmt.Errorf("ringcentral: invalid time %q", s)
+	}
+	m, err := strconv.Atoi(parts[1])
+	if err != nil || m < 0 || m > 59 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("ringcentral: invalid time %q", s)
+	}
+	return h*60 + m, nil
+}
+
+// Contains returns true if t is within the range, interpreting the range in loc
+func (r RangesInfo) Contains(t time.Time, loc *time.Location) (bool, error) {
+	from, err := time.ParseInLocation(rangesTimeFormat, r.From, loc)
+	if err != nil {
+		return false, err
+	}
+	to, err := time.ParseInLocation(rangesTimeFormat, r.To, loc)
+	if err != nil {
+		return false, err
+	}
+	return !t.Before(from) && t.Before(to), nil
+}
+
+// BusinessSchedule combines weekly business hours, holidays and the timezone
+// they are expressed in, so they can be evaluated locally.
+type BusinessSchedule struct {
+	Hours    WeeklyScheduleInfo
+	Holidays []RangesInfo
+	Location *time.Location
+}
+
+// NewBusinessSchedule creates a schedule from business hours and the
+// extension's timezone. An empty timezone name means UTC.
+func NewBusinessSchedule(hours *BusinessHours, tz TimezoneInfo, holidays ...RangesInfo) (*BusinessSchedule, error) {
+	if hours == nil {
+		return nil, errors.New("ringcentral: business hours not set")
+	}
+	loc, err := time.LoadLocation(tz.Name)
+	if err != nil {
+		return nil, fmt.Errorf("ringcentral: unknown timezone %q: %v", tz.Name, err)
+	}
+	return &BusinessSchedule{Hours: hours.Schedule.WeeklyRanges, Holidays: holidays, Location: loc}, nil
+}
+
+// OpenAt returns true if t is within the business hours and not on a holiday
+func (s *BusinessSchedule) OpenAt(t time.Time) (bool, error) {
+	loc := s.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	t = t.In(loc)
+	for _, h := range s.Holidays {
+		in, err := h.Contains(t, loc)
+		if err != nil {
+			return false, err
+		}
+		if in {
+			return false, nil
+		}
+	}
+	if s.Hours.Empty() {
+		return true, nil
+	}
+	for _, i := range s.Hours.Day(t.Weekday()) {
+		in, _, err := i.covers(t)
+		if err != nil {
+			return false, err
+		}
+		if in {
+			return true, nil
+		}
+	}
+	// Ranges spanning midnight started on the previous day
+	for _, i := range s.Hours.Day((t.Weekday() + 6) % 7) {
+		_, in, err := i.covers(t)
+		if err != nil {
+			return false, err
+		}
+		if in {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ExtensionOpenAt fetches the business hours and timezone of the given
+// extension and returns whether it's open at t. Holidays, for example the
+// schedule ranges of a custom answering rule, are treated as closed.
+func (a *API) ExtensionOpenAt(ctx context.Context, ext int64, t time.Time, holidays ...RangesInfo) (bool, error) {
+	e, err := a.GetExtension(ctx, ext)
+	if err != nil {
+		return false, err
+	}
+	hours, err := a.GetBusinessHours(ctx, ext)
+	if err != nil {
+		return false, err
+	}
+	s, err := NewBusinessSchedule(hours, e.RegionalSettings.Timezone, holidays...)
+	if err != nil {
+		return false, err
+	}
+	return s.OpenAt(t)
+}
@@ -0,0 +1,87 @@
+package ringcentral
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusinessScheduleOpenAt(t *testing.T) {
+	hours := &BusinessHours{Schedule: BusinessHoursSchedule{WeeklyRanges: WeeklyScheduleInfo{
+		Monday:   []TimeInterval{{From: "09:00", To: "17:00"}},
+		Friday:   []TimeInterval{{From: "22:00", To: "02:00"}},
+		Saturday: []TimeInterval{{From: "00:00", To: "00:00"}},
+	}}}
+	s, err := NewBusinessSchedule(hours, TimezoneInfo{Name: "America/Los_Angeles"}, RangesInfo{From: "2018-12-24T00:00:00", To: "2018-12-25T00:00:00"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tests := []struct {
+		time string
+		open bool
+	}{
+		{"2018-12-17T10:00:00-08:00", true},  // Monday morning
+		{"2018-12-17T17:00:00-08:00", false}, // Monday closing time
+		{"2018-12-17T18:00:00Z", true},       // Monday 10:00 Pacific
+		{"2018-12-18T10:00:00-08:00", false}, // Tuesday
+		{"2018-12-21T23:00:00-08:00", true},  // Friday night
+		{"2018-12-22T01:00:00-08:00", true},  // Saturday, still in Friday's range
+		{"2018-12-22T03:00:00-08:00", true},  // Saturday, open all day
+		{"2018-12-23T00:30:00-08:00", false}, // Sunday
+		{"2018-12-23T23:59:00-08:00", false}, // Sunday
+		{"2018-12-24T10:00:00-08:00", false}, // Holiday on a Monday
+	}
+	for _, tt := range tests {
+		ts, err := time.Parse(time.RFC3339, tt.time)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		open, err := s.OpenAt(ts)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.open, open, tt.time)
+	}
+
+	// No ranges means open 24/7
+	s, _ = NewBusinessSchedule(&BusinessHours{}, TimezoneInfo{})
+	open, err := s.OpenAt(time.Now())
+	assert.NoError(t, err)
+	assert.True(t, open)
+}
+
+func TestNewBusinessScheduleRequiresHours(t *testing.T) {
+	_, err := NewBusinessSchedule(nil, TimezoneInfo{})
+	assert.Error(t, err)
+}
+
+func TestTimeIntervalContains(t *testing.T) {
+	at := func(clock string) time.Time {
+		ts, _ := time.Parse("15:04", clock)
+		return ts
+	}
+	tests := []struct {
+		interval TimeInterval
+		clock    string
+		in       bool
+	}{
+		{TimeInterval{From: "09:00", To: "17:00"}, "09:00", true},
+		{TimeInterval{From: "09:00", To: "17:00"}, "17:00", false},
+		{TimeInterval{From: "22:00", To: "02:00"}, "23:00", true},
+		{TimeInterval{From: "22:00", To: "02:00"}, "01:59", true},
+		{TimeInterval{From: "22:00", To: "02:00"}, "12:00", false},
+		{TimeInterval{From: "00:00", To: "00:00"}, "00:00", true},
+		{TimeInterval{From: "00:00", To: "00:00"}, "23:59", true},
+		{TimeInterval{From: "00:00", To: "24:00"}, "12:00", true},
+	}
+	for _, tt := range tests {
+		in, err := tt.interval.Contains(at(tt.clock))
+		assert.NoError(t, err)
+		assert.Equal(t, tt.in, in, "%v at %s", tt.interval, tt.clock)
+	}
+}
+
+func TestTimeIntervalInvalid(t *testing.T) {
+	_, err := TimeInterval{From: "9am", To: "17:00"}.Contains(time.Now())
+	assert.Error(t, err)
+}
@@ -0,0 +1,264 @@
+package ringcentral
+
+import (
+	"errors"
+	"net/url"
+
+	"golang.org/x/net/context"
+)
+
+var (
+	// ErrInvalidBlockedNumberID is returned when an operation requires a blocked number id,
+	// or is given a nil blocked number
+	ErrInvalidBlockedNumberID = errors.New("ringcentral: invalid blocked number id")
+)
+
+// CallerBlockingMode tells which callers are blocked
+type CallerBlockingMode string
+
+// Caller blocking modes
+const (
+	// CallerBlockingSpecific blocks the numbers with status Blocked
+	CallerBlockingSpecific CallerBlockingMode = "Specific"
+	// CallerBlockingAll blocks all the numbers except the ones with status Allowed
+	CallerBlockingAll CallerBlockingMode = "All"
+)
+
+// NoCallerIDBlocking is how calls without caller id are handled
+type NoCallerIDBlocking string
+
+// No caller id handling
+const (
+	NoCallerIDBlockCallsAndFaxes NoCallerIDBlocking = "BlockCallsAndFaxes"
+	NoCallerIDBlockFaxes         NoCallerIDBlocking = "BlockFaxes"
+	NoCallerIDAllow              NoCallerIDBlocking = "Allow"
+)
+
+// PayPhonesBlocking is how calls from pay phones are handled
+type PayPhonesBlocking string
+
+// Pay phone handling
+const (
+	PayPhonesBlock PayPhonesBlocking = "Block"
+	PayPhonesAllow PayPhonesBlocking = "Allow"
+)
+
+// BlockedNumberStatus tells whether a number is on the block or the allow list
+type BlockedNumberStatus string
+
+// Blocked number statuses
+const (
+	BlockedNumberBlocked BlockedNumberStatus = "Blocked"
+	BlockedNumberAllowed BlockedNumberStatus = "Allowed"
+)
+
+// CallerBlockingSettings see https://developer.ringcentral.com/api-reference/Get-Caller-Blocking-Settings
+type CallerBlockingSettings struct {
+	Mode       CallerBlockingMode      `json:"mode,omitempty"`
+	NoCallerID NoCallerIDBlocking      `json:"noCallerId,omitempty"`
+	PayPhones  PayPhonesBlocking       `json:"payPhones,omitempty"`
+	Greetings  []BlockedCallerGreeting `json:"greetings,omitempty"`
+}
+
+// BlockedCallerGreeting is the greeting played to blocked callers
+type BlockedCallerGreeting struct {
+	Type   string   `json:"type"`
+	Preset *URIInfo `json:"preset,omitempty"`
+}
+
+// BlockedNumber is a number on the block or allow list of an extension
+type BlockedNumber struct {
+	ID          string              `json:"id,omitempty"`
+	URI         string              `json:"uri,omitempty"`
+	PhoneNumber string              `json:"phoneNumber"`
+	Label       string              `json:"label,omitempty"`
+	Status      BlockedNumberStatus `json:"status,omitempty"`
+}
+
+// BlockedNumberList is a page of blocked or allowed numbers
+type BlockedNumberList struct {
+	URI        string          `json:"uri"`
+	Records    []BlockedNumber `json:"records"`
+	Navigation Navigation      `json:"navigation"`
+	Paging     Paging          `json:"paging"`
+}
+
+// CallerIDFeature is a calling feature with its own outbound caller id
+type CallerIDFeature string
+
+// Caller id features
+const (
+	CallerIDFeatureRingOut             CallerIDFeature = "RingOut"
+	CallerIDFeatureRingMe              CallerIDFeature = "RingMe"
+	CallerIDFeatureCallFlip            CallerIDFeature = "CallFlip"
+	CallerIDFeatureFaxNumber           CallerIDFeature = "FaxNumber"
+	CallerIDFeatureAdditionalSoftphone CallerIDFeature = "AdditionalSoftphone"
+	CallerIDFeatureAlternate           CallerIDFeature = "Alternate"
+	CallerIDFeatureCommonPhone         CallerIDFeature = "CommonPhone"
+	CallerIDFeatureMobileApp           CallerIDFeature = "MobileApp"
+	CallerIDFeatureDelegated           CallerIDFeature = "Delegated"
+)
+
+// CallerIDType is what is presented as the caller id
+type CallerIDType string
+
+// Caller id types
+const (
+	CallerIDTypePhoneNumber     CallerIDType = "PhoneNumber"
+	CallerIDTypeBlocked         CallerIDType = "Blocked"
+	CallerIDTypeCurrentLocation CallerIDType = "CurrentLocation"
+)
+
+// CallerIDInfo see https://developer.ringcentral.com/api-reference/Get-Extension-Caller-ID
+type CallerIDInfo struct {
+	URI                             string              `json:"uri,omitempty"`
+	ByDevice                        []CallerIDByDevice  `json:"byDevice,omitempty"`
+	ByFeature                       []CallerIDByFeature `json:"byFeature,omitempty"`
+	ExtensionNameForOutgoingCalls   *bool               `json:"extensionNameForOutgoingCalls,omitempty"`
+	ExtensionNumberForInternalCalls *bool               `json:"extensionNumberForInternalCalls,omitempty"`
+}
+
+// CallerIDByDevice is the outbound caller id of a device
+type CallerIDByDevice struct {
+	Device   DeviceInfo    `json:"device"`
+	CallerID CallerIDValue `json:"callerId"`
+}
+
+// CallerIDByFeature is the outbound caller id of a feature
+type CallerIDByFeature struct {
+	Feature  CallerIDFeature `json:"feature"`
+	CallerID CallerIDValue   `json:"callerId"`
+}
+
+// CallerIDValue is a caller id setting. PhoneInfo is only used with CallerIDTypePhoneNumber.
+type CallerIDValue struct {
+	Type      CallerIDType       `json:"type"`
+	PhoneInfo *CallerIDPhoneInfo `json:"phoneInfo,omitempty"`
+}
+
+// CallerIDPhoneInfo refers to the phone number used as caller id
+type CallerIDPhoneInfo struct {
+	ID          string `json:"id,omitempty"`
+	URI         string `json:"uri,omitempty"`
+	PhoneNumber string `json:"phoneNumber,omitempty"`
+}
+
+// Feature returns the caller id of the given feature
+func (c *CallerIDInfo) Feature(f CallerIDFeature) (*CallerIDValue, bool) {
+	for i := range c.ByFeature {
+		if c.ByFeature[i].Feature == f {
+			v := c.ByFeature[i].CallerID
+			return &v, true
+		}
+	}
+	return nil, false
+}
+
+// GetCallerBlockingSettings returns the caller blocking settings of the given extension
+func (a *API) GetCallerBlockingSettings(ctx context.Context, ext int64) (*CallerBlockingSettings, error) {
+	var s CallerBlockingSettings
+	if _, err := a.Get(ctx, a.extensionURL(ext, "/caller-blocking"), nil, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// UpdateCallerBlockingSettings updates the caller blocking settings of the given extension
+func (a *API) UpdateCallerBlockingSettings(ctx context.Context, ext int64, s *CallerBlockingSettings) (*CallerBlockingSettings, error) {
+	var updated CallerBlockingSettings
+	if _, err := a.Put(ctx, a.extensionURL(ext, "/caller-blocking"), s, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (a *API) blockedNumberURL(ext int64, id string) string {
+	path := "/caller-blocking/phone-numbers"
+	if id != "" {
+		path += "/" + url.PathEscape(id)
+	}
+	return a.extensionURL(ext, path)
+}
+
+// ListBlockedNumbers returns a page of the blocked and allowed numbers of the
+// given extension. An empty status returns both.
+func (a *API) ListBlockedNumbers(ctx context.Context, ext int64, status BlockedNumberStatus, params url.Values) (*BlockedNumberList, error) {
+	p := url.Values{}
+	for k, v := range params {
+		p[k] = v
+	}
+	if status != "" {
+		p.Set("status", string(status))
+	}
+	var l BlockedNumberList
+	if _, err := a.Get(ctx, a.blockedNumberURL(ext, ""), p, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// GetBlockedNumber returns the given blocked or allowed number
+func (a *API) GetBlockedNumber(ctx context.Context, ext int64, id string) (*BlockedNumber, error) {
+	if id == "" {
+		return nil, ErrInvalidBlockedNumberID
+	}
+	var n BlockedNumber
+	if _, err := a.Get(ctx, a.blockedNumberURL(ext, id), nil, &n); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// AddBlockedNumber adds the number to the block or allow list of the given extension
+func (a *API) AddBlockedNumber(ctx context.Context, ext int64, n *BlockedNumber) (*BlockedNumber, error) {
+	var created BlockedNumber
+	if _, err := a.Post(ctx, a.blockedNumberURL(ext, ""), n, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateBlockedNumber updates the label or status of the given number
+func (a *API) UpdateBlockedNumber(ctx context.Context, ext int64, n *BlockedNumber) (*BlockedNumber, error) {
+	if n == nil || n.ID == "" {
+		return nil, ErrInvalidBlockedNumberID
+	}
+	var updated BlockedNumber
+	if _, err := a.Put(ctx, a.blockedNumberURL(ext, n.ID), n, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteBlockedNumber removes the given number from the block or allow list
+func (a *API) DeleteBlockedNumber(ctx context.Context, ext int64, id string) error {
+	if id == "" {
+		return ErrInvalidBlockedNumberID
+	}
+	_, err := a.Delete(ctx, a.blockedNumberURL(ext, id))
+	return err
+}
+
+// GetCallerID returns the outbound caller id settings of the given extension
+func (a *API) GetCallerID(ctx context.Context, ext int64) (*CallerIDInfo, error) {
+	var c CallerIDInfo
+	if _, err := a.Get(ctx, a.extensionURL(ext, "/caller-id"), nil, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// UpdateCallerID updates the outbound caller id settings of the given
+// extension. Features and devices which aren't listed are left unchanged.
+func (a *API) UpdateCallerID(ctx context.Context, ext int64, c *CallerIDInfo) (*CallerIDInfo, error) {
+	var updated CallerIDInfo
+	if _, err := a.Put(ctx, a.extensionURL(ext, "/caller-id"), c, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// SetFeatureCallerID sets the outbound caller id of a single feature
+func (a *API) SetFeatureCallerID(ctx context.Context, ext int64, f CallerIDFeature, v CallerIDValue) (*CallerIDInfo, error) {
+	return a.UpdateCallerID(ctx, ext, &CallerIDInfo{ByFeature: []CallerIDByFeature{{Feature: f, CallerID: v}}})
+}
@@ -0,0 +1,78 @@
+package ringcentral
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestCallerIDFeature(t *testing.T) {
+	data := `{
+		"byFeature": [
+			{"feature": "RingOut", "callerId": {"type": "PhoneNumber", "phoneInfo": {"id": "33", "phoneNumber": "+16505550100"}}},
+			{"feature": "MobileApp", "callerId": {"type": "Blocked"}}
+		],
+		"extensionNameForOutgoingCalls": true
+	}`
+	var c CallerIDInfo
+	if !assert.NoError(t, json.Unmarshal([]byte(data), &c)) {
+		return
+	}
+	v, ok := c.Feature(CallerIDFeatureRingOut)
+	if assert.True(t, ok) {
+		assert.Equal(t, CallerIDTypePhoneNumber, v.Type)
+		assert.Equal(t, "+16505550100", v.PhoneInfo.PhoneNumber)
+	}
+	v, ok = c.Feature(CallerIDFeatureMobileApp)
+	if assert.True(t, ok) {
+		assert.Equal(t, CallerIDTypeBlocked, v.Type)
+		assert.Nil(t, v.PhoneInfo)
+	}
+	_, ok = c.Feature(CallerIDFeatureFaxNumber)
+	assert.False(t, ok)
+	assert.True(t, *c.ExtensionNameForOutgoingCalls)
+}
+
+func TestBlockedNumberEncode(t *testing.T) {
+	b, err := json.Marshal(&BlockedNumber{PhoneNumber: "+18005550199", Status: BlockedNumberBlocked})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"phoneNumber": "+18005550199", "status": "Blocked"}`, string(b))
+}
+
+func TestUpdateCallerBlockingSettings(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/~/caller-blocking", r.URL.Path)
+		var body map[string]interface{}
+		decodeBody(t, r, &body)
+		assert.Equal(t, map[string]interface{}{"greetings": []interface{}{map[string]interface{}{"type": "BlockedCallersAll", "preset": map[string]interface{}{"id": "107777"}}}}, body)
+		writeJSON(w, `{"mode": "Specific", "greetings": [{"type": "BlockedCallersAll", "preset": {"id": "107777", "uri": "https://platform.ringcentral.com/restapi/v1.0/dictionary/greeting/107777"}}]}`)
+	})
+
+	s, err := a.UpdateCallerBlockingSettings(context.Background(), 0, &CallerBlockingSettings{
+		Greetings: []BlockedCallerGreeting{{Type: "BlockedCallersAll", Preset: &URIInfo{ID: "107777"}}},
+	})
+	if assert.NoError(t, err) && assert.Len(t, s.Greetings, 1) {
+		assert.Equal(t, "https://platform.ringcentral.com/restapi/v1.0/dictionary/greeting/107777", s.Greetings[0].Preset.URI)
+	}
+}
+
+func TestUpdateBlockedNumber(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/~/caller-blocking/phone-numbers/12", r.URL.Path)
+		writeJSON(w, `{"id": "12", "phoneNumber": "+18005550199", "status": "Allowed"}`)
+	})
+
+	n, err := a.UpdateBlockedNumber(context.Background(), 0, &BlockedNumber{ID: "12", Status: BlockedNumberAllowed})
+	if assert.NoError(t, err) {
+		assert.Equal(t, BlockedNumberAllowed, n.Status)
+	}
+	_, err = a.UpdateBlockedNumber(context.Background(), 0, &BlockedNumber{Status: BlockedNumberAllowed})
+	assert.Equal(t, ErrInvalidBlockedNumberID, err)
+	_, err = a.UpdateBlockedNumber(context.Background(), 0, nil)
+	assert.Equal(t, ErrInvalidBlockedNumberID, err)
+}
@@ -0,0 +1,182 @@
+package ringcentral
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"golang.org/x/net/context"
+)
+
+var (
+	// ErrInvalidCallQueueID is returned when an operation requires a call queue id
+	ErrInvalidCallQueueID = errors.New("ringcentral: invalid call queue id")
+)
+
+// CallQueue is a call queue (department extension) of the account
+type CallQueue struct {
+	ID                   string          `json:"id"`
+	URI                  string          `json:"uri,omitempty"`
+	Name                 string          `json:"name"`
+	ExtensionNumber      string          `json:"extensionNumber"`
+	Status               ExtensionStatus `json:"status,omitempty"`
+	ServiceLevelSettings *CallQueueInfo  `json:"serviceLevelSettings,omitempty"`
+}
+
+// CallQueueList is a page of call queues
+type CallQueueList struct {
+	URI        string      `json:"uri"`
+	Records    []CallQueue `json:"records"`
+	Navigation Navigation  `json:"navigation"`
+	Paging     Paging      `json:"paging"`
+}
+
+// CallQueueMember is an extension which is a member of a call queue
+type CallQueueMember struct {
+	ID              string `json:"id"`
+	URI             string `json:"uri,omitempty"`
+	ExtensionNumber string `json:"extensionNumber"`
+}
+
+// CallQueueMemberList is a page of call queue members
+type CallQueueMemberList struct {
+	URI        string            `json:"uri"`
+	Records    []CallQueueMember `json:"records"`
+	Navigation Navigation        `json:"navigation"`
+	Paging     Paging            `json:"paging"`
+}
+
+// CallQueueBulkAssignRequest adds and removes call queue members in a single request
+type CallQueueBulkAssignRequest struct {
+	AddedExtensionIDs   []string `json:"addedExtensionIds,omitempty"`
+	RemovedExtensionIDs []string `json:"removedExtensionIds,omitempty"`
+}
+
+// CallQueueMemberPresence tells whether a member of a queue accepts calls from it
+type CallQueueMemberPresence struct {
+	Member                  CallQueueMemberInfo `json:"member"`
+	AcceptQueueCalls        bool                `json:"acceptQueueCalls"`
+	AcceptCurrentQueueCalls bool                `json:"acceptCurrentQueueCalls"`
+}
+
+// CallQueueMemberInfo identifies a call queue member in presence records
+type CallQueueMemberInfo struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name,omitempty"`
+	ExtensionNumber string    `json:"extensionNumber,omitempty"`
+	Site            *SiteInfo `json:"site,omitempty"`
+}
+
+// CallQueuePresenceList is the member presence of a call queue
+type CallQueuePresenceList struct {
+	Records []CallQueueMemberPresence `json:"records"`
+}
+
+// ExtensionCallQueuePresence tells whether an extension accepts calls from one of its queues
+type ExtensionCallQueuePresence struct {
+	CallQueue   CallQueue `json:"callQueue"`
+	AcceptCalls bool      `json:"acceptCalls"`
+}
+
+// ExtensionCallQueuePresenceList is the queue presence of an extension for all of its queues
+type ExtensionCallQueuePresenceList struct {
+	Records []ExtensionCallQueuePresence `json:"records"`
+}
+
+func (a *API) callQueueURL(id, path string) string {
+	return fmt.Sprintf("/restapi/v1.0/account/%s/call-queues/%s%s", a.AccountID, id, path)
+}
+
+// ListCallQueues returns the call queues of the account
+func (a *API) ListCallQueues(ctx context.Context, params url.Values) (*CallQueueList, error) {
+	var l CallQueueList
+	if _, err := a.Get(ctx, fmt.Sprintf("/restapi/v1.0/account/%s/call-queues", a.AccountID), params, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// GetCallQueue returns the given call queue
+func (a *API) GetCallQueue(ctx context.Context, id string) (*CallQueue, error) {
+	if id == "" {
+		return nil, ErrInvalidCallQueueID
+	}
+	var q CallQueue
+	if _, err := a.Get(ctx, a.callQueueURL(id, ""), nil, &q); err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+// ListCallQueueMembers returns the members of the given call queue
+func (a *API) ListCallQueueMembers(ctx context.Context, id string, params url.Values) (*CallQueueMemberList, error) {
+	if id == "" {
+		return nil, ErrInvalidCallQueueID
+	}
+	var l CallQueueMemberList
+	if _, err := a.Get(ctx, a.callQueueURL(id, "/members"), params, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// BulkAssignCallQueueMembers adds and removes members of the given call queue
+func (a *API) BulkAssignCallQueueMembers(ctx context.Context, id string, req *CallQueueBulkAssignRequest) error {
+	if id == "" {
+		return ErrInvalidCallQueueID
+	}
+	_, err := a.Post(ctx, a.callQueueURL(id, "/bulk-assign"), req, nil)
+	return err
+}
+
+// AddCallQueueMembers adds the given extensions to the call queue
+func (a *API) AddCallQueueMembers(ctx context.Context, id string, extensionIDs ...string) error {
+	return a.BulkAssignCallQueueMembers(ctx, id, &CallQueueBulkAssignRequest{AddedExtensionIDs: extensionIDs})
+}
+
+// RemoveCallQueueMembers removes the given extensions from the call queue
+func (a *API) RemoveCallQueueMembers(ctx context.Context, id string, extensionIDs ...string) error {
+	return a.BulkAssignCallQueueMembers(ctx, id, &CallQueueBulkAssignRequest{RemovedExtensionIDs: extensionIDs})
+}
+
+// GetCallQueuePresence returns whether each member of the call queue accepts queue calls
+func (a *API) GetCallQueuePresence(ctx context.Context, id string) (*CallQueuePresenceList, error) {
+	if id == "" {
+		return nil, ErrInvalidCallQueueID
+	}
+	var l CallQueuePresenceList
+	if _, err := a.Get(ctx, a.callQueueURL(id, "/presence"), nil, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// SetCallQueuePresence turns accepting calls from the call queue on or off for the given members
+func (a *API) SetCallQueuePresence(ctx context.Context, id string, records []CallQueueMemberPresence) (*CallQueuePresenceList, error) {
+	if id == "" {
+		return nil, ErrInvalidCallQueueID
+	}
+	var l CallQueuePresenceList
+	if _, err := a.Put(ctx, a.callQueueURL(id, "/presence"), &CallQueuePresenceList{Records: records}, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// GetExtensionCallQueuePresence returns whether the extension accepts calls from each of its queues
+func (a *API) GetExtensionCallQueuePresence(ctx context.Context, ext int64) (*ExtensionCallQueuePresenceList, error) {
+	var l ExtensionCallQueuePresenceList
+	if _, err := a.Get(ctx, a.extensionURL(ext, "/call-queue-presence"), nil, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// SetExtensionCallQueuePresence turns accepting calls on or off for the given queues of the extension
+func (a *API) SetExtensionCallQueuePresence(ctx context.Context, ext int64, records []ExtensionCallQueuePresence) (*ExtensionCallQueuePresenceList, error) {
+	var l ExtensionCallQueuePresenceList
+	if _, err := a.Put(ctx, a.extensionURL(ext, "/call-queue-presence"), &ExtensionCallQueuePresenceList{Records: records}, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
@@ -0,0 +1,94 @@
+package ringcentral
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestGetCallQueue(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/call-queues/400131009", r.URL.Path)
+		writeJSON(w, `{"id": "400131009", "name": "Support", "extensionNumber": "200", "status": "Enabled", "serviceLevelSettings": {"slaGoal": 80, "slaThresholdSeconds": 30, "includeAbandonedCalls": true, "abandonedThresholdSeconds": 10}}`)
+	})
+
+	q, err := a.GetCallQueue(context.Background(), "400131009")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "200", q.ExtensionNumber)
+		assert.Equal(t, &CallQueueInfo{SLAGoal: 80, SLAThresholdSeconds: 30, IncludeAbandonedCalls: true, AbandonedThresholdSeconds: 10}, q.ServiceLevelSettings)
+	}
+	_, err = a.GetCallQueue(context.Background(), "")
+	assert.Equal(t, ErrInvalidCallQueueID, err)
+}
+
+func TestCallQueueMembers(t *testing.T) {
+	var bodies []CallQueueBulkAssignRequest
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "/restapi/v1.0/account/~/call-queues/400131009/members", r.URL.Path)
+			writeJSON(w, `{"records": [{"id": "101", "extensionNumber": "101"}, {"id": "102", "extensionNumber": "102"}], "paging": {"page": 1, "totalPages": 1}}`)
+		case http.MethodPost:
+			assert.Equal(t, "/restapi/v1.0/account/~/call-queues/400131009/bulk-assign", r.URL.Path)
+			var body CallQueueBulkAssignRequest
+			decodeBody(t, r, &body)
+			bodies = append(bodies, body)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	ctx := context.Background()
+
+	l, err := a.ListCallQueueMembers(ctx, "400131009", nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []CallQueueMember{{ID: "101", ExtensionNumber: "101"}, {ID: "102", ExtensionNumber: "102"}}, l.Records)
+	}
+	assert.NoError(t, a.AddCallQueueMembers(ctx, "400131009", "103"))
+	assert.NoError(t, a.RemoveCallQueueMembers(ctx, "400131009", "101", "102"))
+	assert.Equal(t, []CallQueueBulkAssignRequest{
+		{AddedExtensionIDs: []string{"103"}},
+		{RemovedExtensionIDs: []string{"101", "102"}},
+	}, bodies)
+}
+
+func TestSetCallQueuePresence(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/call-queues/400131009/presence", r.URL.Path)
+		var body map[string]interface{}
+		decodeBody(t, r, &body)
+		assert.Equal(t, map[string]interface{}{"records": []interface{}{map[string]interface{}{
+			"member":                  map[string]interface{}{"id": "101"},
+			"acceptQueueCalls":        false,
+			"acceptCurrentQueueCalls": false,
+		}}}, body)
+		writeJSON(w, `{"records": [{"member": {"id": "101", "name": "Jane Doe", "extensionNumber": "101"}, "acceptQueueCalls": false, "acceptCurrentQueueCalls": false}]}`)
+	})
+
+	l, err := a.SetCallQueuePresence(context.Background(), "400131009", []CallQueueMemberPresence{{Member: CallQueueMemberInfo{ID: "101"}}})
+	if assert.NoError(t, err) && assert.Len(t, l.Records, 1) {
+		assert.Equal(t, "Jane Doe", l.Records[0].Member.Name)
+		assert.False(t, l.Records[0].AcceptQueueCalls)
+	}
+}
+
+func TestSetExtensionCallQueuePresence(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/101/call-queue-presence", r.URL.Path)
+		var body ExtensionCallQueuePresenceList
+		decodeBody(t, r, &body)
+		if assert.Len(t, body.Records, 1) {
+			assert.Equal(t, "400131009", body.Records[0].CallQueue.ID)
+			assert.True(t, body.Records[0].AcceptCalls)
+		}
+		writeJSON(w, `{"records": [{"callQueue": {"id": "400131009", "name": "Support"}, "acceptCalls": true}]}`)
+	})
+
+	l, err := a.SetExtensionCallQueuePresence(context.Background(), 101, []ExtensionCallQueuePresence{{CallQueue: CallQueue{ID: "400131009"}, AcceptCalls: true}})
+	if assert.NoError(t, err) && assert.Len(t, l.Records, 1) {
+		assert.Equal(t, "Support", l.Records[0].CallQueue.Name)
+	}
+}
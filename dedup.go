@@ -0,0 +1,279 @@
+package ringcentral
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// DedupStore remembers the UUIDs of the notifications already handled.
+// Implement it with a shared cache, such as Redis, when several instances
+// receive the same webhook.
+type DedupStore interface {
+	// Seen records uuid for ttl and returns true if it was already recorded
+	Seen(ctx context.Context, uuid string, ttl time.Duration) (bool, error)
+	// Forget removes uuid, so a notification which failed can be handled again
+	Forget(ctx context.Context, uuid string) error
+}
+
+// MemoryDedupStore is an in memory DedupStore. It is safe for concurrent use.
+type MemoryDedupStore struct {
+	mu        sync.Mutex
+	seen      map[string]time.Time
+	nextPurge time.Time
+}
+
+// NewMemoryDedupStore creates an empty in memory store
+func NewMemoryDedupStore() *MemoryDedupStore {
+	return &MemoryDedupStore{seen: map[string]time.Time{}}
+}
+
+// Seen records uuid for ttl and returns true if it was already recorded
+func (s *MemoryDedupStore) Seen(ctx context.Context, uuid string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if now.After(s.nextPurge) {
+		for k, expires := range s.seen {
+			if now.After(expires) {
+				delete(s.seen, k)
+			}
+		}
+		s.nextPurge = now.Add(ttl)
+	}
+	if expires, ok := s.seen[uuid]; ok && now.Before(expires) {
+		return true, nil
+	}
+	s.seen[uuid] = now.Add(ttl)
+	return false, nil
+}
+
+// Forget removes uuid
+func (s *MemoryDedupStore) Forget(ctx context.Context, uuid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.seen, uuid)
+	return nil
+}
+
+// defaultDedupWindow is how long the UUIDs are remembered if Window isn't set
+const defaultDedupWindow = 10 * time.Minute
+
+// Deduplicator is a NotificationHandler middleware which drops notifications
+// whose UUID was already handled within Window. If the next handler fails
+// the UUID is forgotten, so a redelivery is handled again. If the store
+// fails the notification is handled anyway, since a duplicate is better
+// than a lost notification.
+type Deduplicator struct {
+	Next  NotificationHandler
+	Store DedupStore
+	// Window is how long the UUIDs are remembered. Defaults to 10 minutes.
+	Window time.Duration
+}
+
+// NewDeduplicator returns a Deduplicator using an in memory store if store is nil
+func NewDeduplicator(next NotificationHandler, store DedupStore, window time.Duration) *Deduplicator {
+	if store == nil {
+		store = NewMemoryDedupStore()
+	}
+	return &Deduplicator{Next: next, Store: store, Window: window}
+}
+
+// HandleNotification passes n to the next handler unless it is a duplicate
+func (d *Deduplicator) HandleNotification(ctx context.Context, n *Notification) error {
+	if n.UUID == "" {
+		return d.Next.HandleNotification(ctx, n)
+	}
+	window := d.Window
+	if window <= 0 {
+		window = defaultDedupWindow
+	}
+	seen, err := d.Store.Seen(ctx, n.UUID, window)
+	if err == nil && seen {
+		return nil
+	}
+	if err := d.Next.HandleNotification(ctx, n); err != nil {
+		d.Store.Forget(ctx, n.UUID)
+		return err
+	}
+	return nil
+}
+
+// PresenceSequencer is a NotificationHandler middleware which delivers the
+// presence events of each extension in Sequence order. Events older than the
+// last one delivered are dropped. Events after a gap are held for up to Hold
+// waiting for the missing ones, then delivered in order; with a zero Hold
+// they are delivered right away. Other notifications pass through.
+//
+// The next handler is called without holding any lock, so the extensions are
+// delivered independently, and the handler may call back into the sequencer.
+// The events of an extension are still delivered one at a time: an event
+// which becomes ready while another goroutine delivers the extension is
+// delivered by that goroutine. Such events, and held events, are delivered
+// with the context of the delivering goroutine, or a background context
+// when the hold expires; the errors of the next handler for them go to
+// OnError.
+type PresenceSequencer struct {
+	Next    NotificationHandler
+	Hold    time.Duration
+	OnError func(err error)
+
+	mu         sync.Mutex
+	extensions map[string]*presenceSequence
+}
+
+type presenceSequence struct {
+	subscriptionID string
+	last           int
+	held           []sequencedNotification
+	timer          *time.Timer
+	ready          []*Notification
+	delivering     bool
+}
+
+type sequencedNotification struct {
+	sequence int
+	n        *Notification
+}
+
+var presencePatterns = []eventPattern{
+	mustParseEventPattern(EventPatternExtensionPresence),
+	mustParseEventPattern(EventPatternAccountPresence),
+}
+
+func mustParseEventPattern(s string) eventPattern {
+	p, err := parseEventPattern(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// NewPresenceSequencer returns a sequencer holding out of order events for up to hold
+func NewPresenceSequencer(next NotificationHandler, hold time.Duration) *PresenceSequencer {
+	return &PresenceSequencer{Next: next, Hold: hold, extensions: map[string]*presenceSequence{}}
+}
+
+// HandleNotification delivers, holds or drops n
+func (s *PresenceSequencer) HandleNotification(ctx context.Context, n *Notification) error {
+	ext, seq, ok := presenceSequenceOf(n)
+	if !ok {
+		return s.Next.HandleNotification(ctx, n)
+	}
+
+	s.mu.Lock()
+	if s.extensions == nil {
+		s.extensions = map[string]*presenceSequence{}
+	}
+	ps := s.extensions[ext]
+	if ps == nil || ps.subscriptionID != n.SubscriptionID {
+		// Sequences are per subscription, so start over with a new one
+		if ps != nil && ps.timer != nil {
+			ps.timer.Stop()
+		}
+		ps = &presenceSequence{subscriptionID: n.SubscriptionID, last: seq - 1}
+		s.extensions[ext] = ps
+	}
+
+	switch {
+	case seq <= ps.last:
+		s.mu.Unlock()
+		return nil
+	case seq == ps.last+1 || s.Hold <= 0:
+		ps.last = seq
+		ps.ready = append(ps.ready, n)
+		s.release(ps, false)
+		return s.deliver(ctx, ps, n)
+	}
+	defer s.mu.Unlock()
+	for _, h := range ps.held {
+		if h.sequence == seq {
+			return nil
+		}
+	}
+	ps.held = append(ps.held, sequencedNotification{sequence: seq, n: n})
+	sort.Slice(ps.held, func(i, j int) bool { return ps.held[i].sequence < ps.held[j].sequence })
+	if ps.timer == nil {
+		ps.timer = time.AfterFunc(s.Hold, func() {
+			s.mu.Lock()
+			ps.timer = nil
+			s.release(ps, true)
+			s.deliver(context.Background(), ps, nil)
+		})
+	}
+	return nil
+}
+
+// deliver passes the ready events of ps to the next handler. It must be
+// called with s.mu held, and unlocks it. If another goroutine is already
+// delivering ps, the events are left to it. The error of the next handler
+// for n is returned, the others go to OnError.
+func (s *PresenceSequencer) deliver(ctx context.Context, ps *presenceSequence, n *Notification) error {
+	if ps.delivering {
+		s.mu.Unlock()
+		return nil
+	}
+	ps.delivering = true
+	var err error
+	for len(ps.ready) > 0 {
+		next := ps.ready[0]
+		ps.ready = ps.ready[1:]
+		s.mu.Unlock()
+		nerr := s.Next.HandleNotification(ctx, next)
+		switch {
+		case next == n:
+			err = nerr
+		case nerr != nil && s.OnError != nil:
+			s.OnError(nerr)
+		}
+		s.mu.Lock()
+	}
+	ps.delivering = false
+	s.mu.Unlock()
+	return err
+}
+
+// release moves the held events following the last one delivered to the
+// ready events. If all is true the gaps are skipped and every held event is
+// released.
+func (s *PresenceSequencer) release(ps *presenceSequence, all bool) {
+	for len(ps.held) > 0 {
+		h := ps.held[0]
+		if h.sequence <= ps.last {
+			ps.held = ps.held[1:]
+			continue
+		}
+		if !all && h.sequence != ps.last+1 {
+			break
+		}
+		ps.held = ps.held[1:]
+		ps.last = h.sequence
+		ps.ready = append(ps.ready, h.n)
+	}
+	if len(ps.held) == 0 && ps.timer != nil {
+		ps.timer.Stop()
+		ps.timer = nil
+	}
+}
+
+// presenceSequenceOf returns the extension and sequence of presence events
+func presenceSequenceOf(n *Notification) (string, int, bool) {
+	matched := false
+	for _, p := range presencePatterns {
+		matched = matched || p.match(n.Event)
+	}
+	if !matched {
+		return "", 0, false
+	}
+	var body struct {
+		ExtensionID string `json:"extensionId"`
+		Sequence    int    `json:"sequence"`
+	}
+	if err := json.Unmarshal(n.Body, &body); err != nil || body.ExtensionID == "" || body.Sequence == 0 {
+		return "", 0, false
+	}
+	return body.ExtensionID, body.Sequence, true
+}
@@ -0,0 +1,154 @@
+package ringcentral
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+type recordingHandler struct {
+	mu   sync.Mutex
+	got  []string
+	fail bool
+}
+
+func (r *recordingHandler) HandleNotification(ctx context.Context, n *Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail {
+		return errors.New("boom")
+	}
+	r.got = append(r.got, n.UUID)
+	return nil
+}
+
+func (r *recordingHandler) received() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.got...)
+}
+
+func TestDeduplicator(t *testing.T) {
+	ctx := context.Background()
+	rec := &recordingHandler{}
+	d := NewDeduplicator(rec, nil, time.Minute)
+
+	assert.NoError(t, d.HandleNotification(ctx, &Notification{UUID: "a"}))
+	assert.NoError(t, d.HandleNotification(ctx, &Notification{UUID: "a"}))
+	assert.NoError(t, d.HandleNotification(ctx, &Notification{UUID: "b"}))
+
+	rec.fail = true
+	assert.Error(t, d.HandleNotification(ctx, &Notification{UUID: "c"}))
+	rec.fail = false
+	assert.NoError(t, d.HandleNotification(ctx, &Notification{UUID: "c"}))
+	assert.Equal(t, []string{"a", "b", "c"}, rec.received())
+
+	store := NewMemoryDedupStore()
+	seen, _ := store.Seen(ctx, "x", time.Millisecond)
+	assert.False(t, seen)
+	time.Sleep(2 * time.Millisecond)
+	seen, _ = store.Seen(ctx, "x", time.Minute)
+	assert.False(t, seen)
+}
+
+func presenceNotification(ext string, seq int) *Notification {
+	n, _ := ParseNotification([]byte(`{"uuid": "` + ext + `-` + strconv.Itoa(seq) + `", "subscriptionId": "s-1", "event": "/restapi/v1.0/account/1/extension/` + ext + `/presence", "body": {"extensionId": "` + ext + `", "sequence": ` + strconv.Itoa(seq) + `}}`))
+	return n
+}
+
+func TestPresenceSequencer(t *testing.T) {
+	ctx := context.Background()
+	rec := &recordingHandler{}
+	s := NewPresenceSequencer(rec, 30*time.Millisecond)
+
+	for _, n := range []*Notification{
+		presenceNotification("2", 1),
+		presenceNotification("2", 3),
+		presenceNotification("9", 7),
+		presenceNotification("2", 2),
+		presenceNotification("2", 2),
+		presenceNotification("2", 5),
+		presenceNotification("2", 4),
+		presenceNotification("2", 1),
+		presenceNotification("2", 7),
+		{UUID: "other", Event: "/restapi/v1.0/account/1/extension/2/message-store"},
+	} {
+		assert.NoError(t, s.HandleNotification(ctx, n))
+	}
+	assert.Equal(t, []string{"2-1", "9-7", "2-2", "2-3", "2-4", "2-5", "other"}, rec.received())
+
+	// The gap before 7 is skipped once the hold expires, then 6 is stale
+	time.Sleep(60 * time.Millisecond)
+	assert.NoError(t, s.HandleNotification(ctx, presenceNotification("2", 6)))
+	assert.Equal(t, []string{"2-1", "9-7", "2-2", "2-3", "2-4", "2-5", "other", "2-7"}, rec.received())
+}
+
+func TestPresenceSequencerNoHold(t *testing.T) {
+	ctx := context.Background()
+	rec := &recordingHandler{}
+	s := NewPresenceSequencer(rec, 0)
+	for _, seq := range []int{1, 3, 2, 4} {
+		assert.NoError(t, s.HandleNotification(ctx, presenceNotification("2", seq)))
+	}
+	assert.Equal(t, []string{"2-1", "2-3", "2-4"}, rec.received())
+}
+
+func TestDeduplicatorDefaultWindow(t *testing.T) {
+	ctx := context.Background()
+	rec := &recordingHandler{}
+	d := &Deduplicator{Next: rec, Store: NewMemoryDedupStore()}
+	assert.NoError(t, d.HandleNotification(ctx, &Notification{UUID: "a"}))
+	assert.NoError(t, d.HandleNotification(ctx, &Notification{UUID: "a"}))
+	assert.Equal(t, []string{"a"}, rec.received())
+}
+
+func TestPresenceSequencerConcurrentExtensions(t *testing.T) {
+	ctx := context.Background()
+	blocked, release := make(chan struct{}), make(chan struct{})
+	rec := &recordingHandler{}
+	s := NewPresenceSequencer(NotificationHandlerFunc(func(ctx context.Context, n *Notification) error {
+		if n.UUID == "2-1" {
+			close(blocked)
+			<-release
+		}
+		return rec.HandleNotification(ctx, n)
+	}), time.Minute)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.HandleNotification(ctx, presenceNotification("2", 1))
+	}()
+	<-blocked
+
+	// Other extensions aren't held up by a slow handler, and the events of
+	// the busy extension are delivered in order by its delivering goroutine
+	assert.NoError(t, s.HandleNotification(ctx, presenceNotification("9", 1)))
+	assert.NoError(t, s.HandleNotification(ctx, presenceNotification("2", 2)))
+	assert.Equal(t, []string{"9-1"}, rec.received())
+	close(release)
+	<-done
+	assert.Equal(t, []string{"9-1", "2-1", "2-2"}, rec.received())
+}
+
+func TestPresenceSequencerReentrant(t *testing.T) {
+	ctx := context.Background()
+	rec := &recordingHandler{}
+	var s *PresenceSequencer
+	s = NewPresenceSequencer(NotificationHandlerFunc(func(ctx context.Context, n *Notification) error {
+		if n.UUID == "2-1" {
+			// The handler may call back into the sequencer
+			s.HandleNotification(ctx, presenceNotification("2", 2))
+			s.HandleNotification(ctx, presenceNotification("9", 1))
+		}
+		return rec.HandleNotification(ctx, n)
+	}), time.Minute)
+
+	assert.NoError(t, s.HandleNotification(ctx, presenceNotification("2", 1)))
+	assert.Equal(t, []string{"9-1", "2-1", "2-2"}, rec.received())
+}
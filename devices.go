@@ -0,0 +1,215 @@
+package ringcentral
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+var (
+	// ErrInvalidDeviceID is returned when an operation requires a device id
+	ErrInvalidDeviceID = errors.New("ringcentral: invalid device id")
+)
+
+// DeviceType is the kind of a device
+type DeviceType string
+
+// Device types
+const (
+	DeviceTypeHardPhone    DeviceType = "HardPhone"
+	DeviceTypeSoftPhone    DeviceType = "SoftPhone"
+	DeviceTypeOtherPhone   DeviceType = "OtherPhone"
+	DeviceTypeMobileDevice DeviceType = "MobileDevice"
+	DeviceTypeBLA          DeviceType = "BLA"
+	DeviceTypePaging       DeviceType = "Paging"
+	DeviceTypeWebPhone     DeviceType = "WebPhone"
+	DeviceTypeWebRTC       DeviceType = "WebRTC"
+	DeviceTypeRoom         DeviceType = "Room"
+)
+
+// DeviceStatus tells whether a device is registered
+type DeviceStatus string
+
+// Device statuses
+const (
+	DeviceStatusOnline  DeviceStatus = "Online"
+	DeviceStatusOffline DeviceStatus = "Offline"
+)
+
+// LinePooling is the line pooling role of a device
+type LinePooling string
+
+// Line pooling roles
+const (
+	LinePoolingHost  LinePooling = "Host"
+	LinePoolingGuest LinePooling = "Guest"
+	LinePoolingNone  LinePooling = "None"
+)
+
+// LineType is the type of a phone line of a device
+type LineType string
+
+// Line types
+const (
+	LineTypeStandalone     LineType = "Standalone"
+	LineTypeStandaloneFree LineType = "StandaloneFree"
+	LineTypeBlaPrimary     LineType = "BlaPrimary"
+	LineTypeBlaSecondary   LineType = "BlaSecondary"
+)
+
+// Device see https://developer.ringcentral.com/api-reference/Get-Device
+type Device struct {
+	ID                      string                `json:"id,omitempty"`
+	URI                     string                `json:"uri,omitempty"`
+	SKU                     string                `json:"sku,omitempty"`
+	Type                    DeviceType            `json:"type,omitempty"`
+	Name                    string                `json:"name,omitempty"`
+	Serial                  string                `json:"serial,omitempty"`
+	ComputerName            string                `json:"computerName,omitempty"`
+	Model                   *DeviceModel          `json:"model,omitempty"`
+	Extension               *ExtensionRef         `json:"extension,omitempty"`
+	EmergencyServiceAddress *EmergencyAddressInfo `json:"emergencyServiceAddress,omitempty"`
+	Emergency               *DeviceEmergencyInfo  `json:"emergency,omitempty"`
+	PhoneLines              []DevicePhoneLine     `json:"phoneLines,omitempty"`
+	UseAsCommonPhone        *bool                 `json:"useAsCommonPhone,omitempty"`
+	LinePooling             LinePooling           `json:"linePooling,omitempty"`
+	InCompanyNet            *bool                 `json:"inCompanyNet,omitempty"`
+	Site                    *SiteInfo             `json:"site,omitempty"`
+	Status                  DeviceStatus          `json:"status,omitempty"`
+	LastLocationReportTime  *time.Time            `json:"lastLocationReportTime,omitempty"`
+	BoxBillingID            int64                 `json:"boxBillingId,omitempty"`
+}
+
+// DeviceModel is the hardware model of a device
+type DeviceModel struct {
+	ID     string        `json:"id"`
+	Name   string        `json:"name"`
+	Addons []DeviceAddon `json:"addons,omitempty"`
+}
+
+// DeviceAddon is an addon, for example a sidecar, attached to a device
+type DeviceAddon struct {
+	ID    string `json:"id"`
+	Count int    `json:"count"`
+}
+
+// DevicePhoneLine is a phone line of a device
+type DevicePhoneLine struct {
+	LineType  LineType        `json:"lineType,omitempty"`
+	PhoneInfo PhoneNumberInfo `json:"phoneInfo"`
+}
+
+// EmergencyAddressInfo is the address reported to emergency services for a device
+type EmergencyAddressInfo struct {
+	CustomerName   string `json:"customerName,omitempty"`
+	Street         string `json:"street,omitempty"`
+	Street2        string `json:"street2,omitempty"`
+	City           string `json:"city,omitempty"`
+	Zip            string `json:"zip,omitempty"`
+	State          string `json:"state,omitempty"`
+	StateID        string `json:"stateId,omitempty"`
+	StateIsoCode   string `json:"stateIsoCode,omitempty"`
+	StateName      string `json:"stateName,omitempty"`
+	Country        string `json:"country,omitempty"`
+	CountryID      string `json:"countryId,omitempty"`
+	CountryIsoCode string `json:"countryIsoCode,omitempty"`
+	CountryName    string `json:"countryName,omitempty"`
+	OutOfCountry   bool   `json:"outOfCountry,omitempty"`
+}
+
+// DeviceList is a page of devices
+type DeviceList struct {
+	URI        string     `json:"uri"`
+	Records    []Device   `json:"records"`
+	Navigation Navigation `json:"navigation"`
+	Paging     Paging     `json:"paging"`
+}
+
+// DeviceUpdateRequest is the request body for UpdateDevice. Only non-empty fields are updated.
+type DeviceUpdateRequest struct {
+	Name                    string                  `json:"name,omitempty"`
+	EmergencyServiceAddress *EmergencyAddressInfo   `json:"emergencyServiceAddress,omitempty"`
+	Emergency               *DeviceEmergencyInfo    `json:"emergency,omitempty"`
+	Extension               *ExtensionRef           `json:"extension,omitempty"`
+	PhoneLines              *DevicePhoneLinesUpdate `json:"phoneLines,omitempty"`
+	UseAsCommonPhone        *bool                   `json:"useAsCommonPhone,omitempty"`
+}
+
+// DevicePhoneLinesUpdate replaces the phone lines of a device
+type DevicePhoneLinesUpdate struct {
+	PhoneLines []DevicePhoneLineRef `json:"phoneLines"`
+}
+
+// DevicePhoneLineRef refers to a phone number assigned as a device line
+type DevicePhoneLineRef struct {
+	PhoneInfo URIInfo `json:"phoneInfo"`
+}
+
+func (a *API) deviceURL(id string) string {
+	urlStr := fmt.Sprintf("/restapi/v1.0/account/%s/device", a.AccountID)
+	if id != "" {
+		urlStr += "/" + url.PathEscape(id)
+	}
+	return urlStr
+}
+
+// ListAccountDevices returns a page of the devices of the account
+func (a *API) ListAccountDevices(ctx context.Context, params url.Values) (*DeviceList, error) {
+	var l DeviceList
+	if _, err := a.Get(ctx, a.deviceURL(""), params, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// AllAccountDevices fetches every page of the devices of the account
+func (a *API) AllAccountDevices(ctx context.Context) ([]Device, error) {
+	var all []Device
+	for page := 1; ; page++ {
+		l, err := a.ListAccountDevices(ctx, url.Values{"page": []string{strconv.Itoa(page)}})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, l.Records...)
+		if !hasNextPage(len(l.Records), l.Paging, l.Navigation) {
+			return all, nil
+		}
+	}
+}
+
+// ListExtensionDevices returns the devices of the given extension
+func (a *API) ListExtensionDevices(ctx context.Context, ext int64, params url.Values) (*DeviceList, error) {
+	var l DeviceList
+	if _, err := a.Get(ctx, a.extensionURL(ext, "/device"), params, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// GetDevice returns the given device
+func (a *API) GetDevice(ctx context.Context, id string) (*Device, error) {
+	if id == "" {
+		return nil, ErrInvalidDeviceID
+	}
+	var d Device
+	if _, err := a.Get(ctx, a.deviceURL(id), nil, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// UpdateDevice updates the name, emergency address, extension or phone lines of the given device
+func (a *API) UpdateDevice(ctx context.Context, id string, req *DeviceUpdateRequest) (*Device, error) {
+	if id == "" {
+		return nil, ErrInvalidDeviceID
+	}
+	var d Device
+	if _, err := a.Put(ctx, a.deviceURL(id), req, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
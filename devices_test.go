@@ -0,0 +1,56 @@
+package ringcentral
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestDeviceDecode(t *testing.T) {
+	data := `{
+		"id": "800130005",
+		"type": "HardPhone",
+		"name": "Polycom VVX 450",
+		"serial": "0004F2A1B2C3",
+		"model": {"id": "81", "name": "Polycom VVX 450", "addons": [{"id": "1", "count": 2}]},
+		"extension": {"id": "400131005", "extensionNumber": "101"},
+		"emergencyServiceAddress": {"street": "20 Davis Drive", "city": "Belmont", "zip": "94002", "countryIsoCode": "US"},
+		"phoneLines": [{"lineType": "Standalone", "phoneInfo": {"id": 1, "phoneNumber": "+16505550100"}}],
+		"linePooling": "None",
+		"status": "Online"
+	}`
+	var d Device
+	if !assert.NoError(t, json.Unmarshal([]byte(data), &d)) {
+		return
+	}
+	assert.Equal(t, DeviceTypeHardPhone, d.Type)
+	assert.Equal(t, "0004F2A1B2C3", d.Serial)
+	assert.Equal(t, 2, d.Model.Addons[0].Count)
+	assert.Equal(t, "101", d.Extension.ExtensionNumber)
+	assert.Equal(t, "Belmont", d.EmergencyServiceAddress.City)
+	assert.Equal(t, LineTypeStandalone, d.PhoneLines[0].LineType)
+	assert.Equal(t, LinePoolingNone, d.LinePooling)
+	assert.Equal(t, DeviceStatusOnline, d.Status)
+}
+
+func TestUpdateDevice(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/device/800130005", r.URL.Path)
+		var body map[string]interface{}
+		decodeBody(t, r, &body)
+		assert.Equal(t, map[string]interface{}{"name": "Front desk", "extension": map[string]interface{}{"id": "400131006"}}, body)
+		writeJSON(w, `{"id": "800130005", "name": "Front desk", "extension": {"id": "400131006", "extensionNumber": "102"}}`)
+	})
+
+	d, err := a.UpdateDevice(context.Background(), "800130005", &DeviceUpdateRequest{Name: "Front desk", Extension: &ExtensionRef{ID: "400131006"}})
+	if assert.NoError(t, err) && assert.NotNil(t, d.Extension) {
+		assert.Equal(t, "102", d.Extension.ExtensionNumber)
+	}
+
+	_, err = a.UpdateDevice(context.Background(), "", &DeviceUpdateRequest{})
+	assert.Equal(t, ErrInvalidDeviceID, err)
+}
@@ -0,0 +1,287 @@
+package ringcentral
+
+import (
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// StateInfo is a state or province of a country
+type StateInfo struct {
+	ID      string      `json:"id"`
+	URI     string      `json:"uri"`
+	Name    string      `json:"name"`
+	IsoCode string      `json:"isoCode"`
+	Country CountryInfo `json:"country"`
+}
+
+type countryList struct {
+	Records    []CountryInfo `json:"records"`
+	Navigation Navigation    `json:"navigation"`
+	Paging     Paging        `json:"paging"`
+}
+
+type stateList struct {
+	Records    []StateInfo `json:"records"`
+	Navigation Navigation  `json:"navigation"`
+	Paging     Paging      `json:"paging"`
+}
+
+type timezoneList struct {
+	Records    []TimezoneInfo `json:"records"`
+	Navigation Navigation     `json:"navigation"`
+	Paging     Paging         `json:"paging"`
+}
+
+type languageList struct {
+	Records    []LanguageInfo `json:"records"`
+	Navigation Navigation     `json:"navigation"`
+	Paging     Paging         `json:"paging"`
+}
+
+type formattingLocaleList struct {
+	Records    []FormattingLocaleInfo `json:"records"`
+	Navigation Navigation             `json:"navigation"`
+	Paging     Paging                 `json:"paging"`
+}
+
+func dictionaryParams(page int, params url.Values) url.Values {
+	p := url.Values{}
+	for k, v := range params {
+		p[k] = v
+	}
+	p.Set("page", strconv.Itoa(page))
+	p.Set("perPage", "1000")
+	return p
+}
+
+// ListCountries returns all the countries known to RingCentral
+func (a *API) ListCountries(ctx context.Context) ([]CountryInfo, error) {
+	var all []CountryInfo
+	for page := 1; ; page++ {
+		var l countryList
+		if _, err := a.Get(ctx, "/restapi/v1.0/dictionary/country", dictionaryParams(page, nil), &l); err != nil {
+			return nil, err
+		}
+		all = append(all, l.Records...)
+		if !hasNextPage(len(l.Records), l.Paging, l.Navigation) {
+			return all, nil
+		}
+	}
+}
+
+// GetCountry returns the given country
+func (a *API) GetCountry(ctx context.Context, id string) (*CountryInfo, error) {
+	var c CountryInfo
+	if _, err := a.Get(ctx, "/restapi/v1.0/dictionary/country/"+url.PathEscape(id), nil, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// ListStates returns the states of the given country, or of all countries if countryID is empty
+func (a *API) ListStates(ctx context.Context, countryID string) ([]StateInfo, error) {
+	var params url.Values
+	if countryID != "" {
+		params = url.Values{"countryId": []string{countryID}}
+	}
+	var all []StateInfo
+	for page := 1; ; page++ {
+		var l stateList
+		if _, err := a.Get(ctx, "/restapi/v1.0/dictionary/state", dictionaryParams(page, params), &l); err != nil {
+			return nil, err
+		}
+		all = append(all, l.Records...)
+		if !hasNextPage(len(l.Records), l.Paging, l.Navigation) {
+			return all, nil
+		}
+	}
+}
+
+// ListTimezones returns all the timezones known to RingCentral
+func (a *API) ListTimezones(ctx context.Context) ([]TimezoneInfo, error) {
+	var all []TimezoneInfo
+	for page := 1; ; page++ {
+		var l timezoneList
+		if _, err := a.Get(ctx, "/restapi/v1.0/dictionary/timezone", dictionaryParams(page, nil), &l); err != nil {
+			return nil, err
+		}
+		all = append(all, l.Records...)
+		if !hasNextPage(len(l.Records), l.Paging, l.Navigation) {
+			return all, nil
+		}
+	}
+}
+
+// ListLanguages returns all the languages known to RingCentral
+func (a *API) ListLanguages(ctx context.Context) ([]LanguageInfo, error) {
+	var all []LanguageInfo
+	for page := 1; ; page++ {
+		var l languageList
+		if _, err := a.Get(ctx, "/restapi/v1.0/dictionary/language", dictionaryParams(page, nil), &l); err != nil {
+			return nil, err
+		}
+		all = append(all, l.Records...)
+		if !hasNextPage(len(l.Records), l.Paging, l.Navigation) {
+			return all, nil
+		}
+	}
+}
+
+// ListFormattingLocales returns the locales which can be used to format
+// numbers and dates.
+func (a *API) ListFormattingLocales(ctx context.Context) ([]FormattingLocaleInfo, error) {
+	var all []FormattingLocaleInfo
+	for page := 1; ; page++ {
+		var l formattingLocaleList
+		if _, err := a.Get(ctx, "/restapi/v1.0/dictionary/formatting-locale", dictionaryParams(page, nil), &l); err != nil {
+			return nil, err
+		}
+		all = append(all, l.Records...)
+		if !hasNextPage(len(l.Records), l.Paging, l.Navigation) {
+			return all, nil
+		}
+	}
+}
+
+// Dictionary caches the dictionary lookups of an API client, since countries,
+// states, timezones and languages rarely change. It is safe for concurrent use;
+// each list is fetched at most once at a time, without blocking the others.
+// The lists returned are copies, so callers may modify them.
+type Dictionary struct {
+	api *API
+	ttl time.Duration
+
+	mu    sync.Mutex
+	slots map[string]*cachedValue
+}
+
+// cachedValue is a cached dictionary list. lock is held while the list is
+// fetched, as a channel so waiting callers can give up when ctx is done.
+type cachedValue struct {
+	lock    chan struct{}
+	value   interface{}
+	expires time.Time
+	ok      bool
+}
+
+// NewDictionary creates a dictionary cache. Entries are refreshed after ttl;
+// a ttl of 0 caches them for the lifetime of the Dictionary.
+func NewDictionary(a *API, ttl time.Duration) *Dictionary {
+	return &Dictionary{api: a, ttl: ttl, slots: map[string]*cachedValue{}}
+}
+
+func (d *Dictionary) slot(key string) *cachedValue {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	c, ok := d.slots[key]
+	if !ok {
+		c = &cachedValue{lock: make(chan struct{}, 1)}
+		d.slots[key] = c
+	}
+	return c
+}
+
+func (d *Dictionary) get(ctx context.Context, key string, fetch func(context.Context) (interface{}, error)) (interface{}, error) {
+	c := d.slot(key)
+	select {
+	case c.lock <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-c.lock }()
+	if c.ok && (d.ttl <= 0 || time.Now().Before(c.expires)) {
+		return c.value, nil
+	}
+	v, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.value, c.expires, c.ok = v, time.Now().Add(d.ttl), true
+	return v, nil
+}
+
+// Countries returns the cached list of countries
+func (d *Dictionary) Countries(ctx context.Context) ([]CountryInfo, error) {
+	v, err := d.get(ctx, "countries", func(ctx context.Context) (interface{}, error) { return d.api.ListCountries(ctx) })
+	if err != nil {
+		return nil, err
+	}
+	return append([]CountryInfo(nil), v.([]CountryInfo)...), nil
+}
+
+// Country returns the country with the given ISO code, for example "US"
+func (d *Dictionary) Country(ctx context.Context, isoCode string) (*CountryInfo, bool, error) {
+	countries, err := d.Countries(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range countries {
+		if countries[i].IsoCode == isoCode {
+			c := countries[i]
+			return &c, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// States returns the cached list of states of the given country
+func (d *Dictionary) States(ctx context.Context, countryID string) ([]StateInfo, error) {
+	v, err := d.get(ctx, "states/"+countryID, func(ctx context.Context) (interface{}, error) { return d.api.ListStates(ctx, countryID) })
+	if err != nil {
+		return nil, err
+	}
+	return append([]StateInfo(nil), v.([]StateInfo)...), nil
+}
+
+// Timezones returns the cached list of timezones
+func (d *Dictionary) Timezones(ctx context.Context) ([]TimezoneInfo, error) {
+	v, err := d.get(ctx, "timezones", func(ctx context.Context) (interface{}, error) { return d.api.ListTimezones(ctx) })
+	if err != nil {
+		return nil, err
+	}
+	return append([]TimezoneInfo(nil), v.([]TimezoneInfo)...), nil
+}
+
+// Timezone returns the timezone with the given name, for example "US/Pacific"
+func (d *Dictionary) Timezone(ctx context.Context, name string) (*TimezoneInfo, bool, error) {
+	timezones, err := d.Timezones(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range timezones {
+		if timezones[i].Name == name {
+			tz := timezones[i]
+			return &tz, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// Languages returns the cached list of languages
+func (d *Dictionary) Languages(ctx context.Context) ([]LanguageInfo, error) {
+	v, err := d.get(ctx, "languages", func(ctx context.Context) (interface{}, error) { return d.api.ListLanguages(ctx) })
+	if err != nil {
+		return nil, err
+	}
+	return append([]LanguageInfo(nil), v.([]LanguageInfo)...), nil
+}
+
+// FormattingLocales returns the cached list of formatting locales
+func (d *Dictionary) FormattingLocales(ctx context.Context) ([]FormattingLocaleInfo, error) {
+	v, err := d.get(ctx, "formattingLocales", func(ctx context.Context) (interface{}, error) { return d.api.ListFormattingLocales(ctx) })
+	if err != nil {
+		return nil, err
+	}
+	return append([]FormattingLocaleInfo(nil), v.([]FormattingLocaleInfo)...), nil
+}
+
+// Reset drops all the cached entries
+func (d *Dictionary) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.slots = map[string]*cachedValue{}
+}
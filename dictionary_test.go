@@ -0,0 +1,122 @@
+package ringcentral
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestListCountries(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/restapi/v1.0/dictionary/country", r.URL.Path)
+		assert.Equal(t, "1000", r.URL.Query().Get("perPage"))
+		switch r.URL.Query().Get("page") {
+		case "1":
+			writeJSON(w, `{"records": [{"id": "1", "isoCode": "US", "callingCode": "1"}], "paging": {"page": 1, "totalPages": 2}}`)
+		default:
+			writeJSON(w, `{"records": [{"id": "39", "isoCode": "CA", "callingCode": "1"}], "paging": {"page": 2, "totalPages": 2}}`)
+		}
+	})
+
+	countries, err := a.ListCountries(context.Background())
+	if assert.NoError(t, err) && assert.Len(t, countries, 2) {
+		assert.Equal(t, "US", countries[0].IsoCode)
+		assert.Equal(t, "CA", countries[1].IsoCode)
+	}
+}
+
+func TestListStates(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/restapi/v1.0/dictionary/state", r.URL.Path)
+		assert.Equal(t, "39", r.URL.Query().Get("countryId"))
+		writeJSON(w, `{"records": [{"id": "61", "name": "Ontario", "isoCode": "ON", "country": {"id": "39", "isoCode": "CA"}}], "paging": {"page": 1, "totalPages": 1}}`)
+	})
+
+	states, err := a.ListStates(context.Background(), "39")
+	if assert.NoError(t, err) && assert.Len(t, states, 1) {
+		assert.Equal(t, "ON", states[0].IsoCode)
+		assert.Equal(t, "CA", states[0].Country.IsoCode)
+	}
+}
+
+func TestListFormattingLocales(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/restapi/v1.0/dictionary/formatting-locale", r.URL.Path)
+		writeJSON(w, `{"records": [{"id": "1033", "localeCode": "en-US", "name": "English (United States)"}], "paging": {"page": 1, "totalPages": 1}}`)
+	})
+
+	locales, err := a.ListFormattingLocales(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, []FormattingLocaleInfo{{ID: "1033", LocaleCode: "en-US", Name: "English (United States)"}}, locales)
+	}
+}
+
+func TestDictionaryCache(t *testing.T) {
+	var requests int32
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		writeJSON(w, `{"records": [{"id": "58", "name": "US/Pacific"}], "paging": {"page": 1, "totalPages": 1}}`)
+	})
+	ctx := context.Background()
+	d := NewDictionary(a, time.Hour)
+
+	tz, ok, err := d.Timezone(ctx, "US/Pacific")
+	if assert.NoError(t, err) && assert.True(t, ok) {
+		assert.Equal(t, "58", tz.ID)
+	}
+	_, ok, _ = d.Timezone(ctx, "Europe/Berlin")
+	assert.False(t, ok)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	d.slot("timezones").expires = time.Now().Add(-time.Second)
+	d.Timezones(ctx)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	d.Reset()
+	d.Timezones(ctx)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestDictionaryReturnsCopies(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, `{"records": [{"id": "1", "isoCode": "US"}], "paging": {"page": 1, "totalPages": 1}}`)
+	})
+	ctx := context.Background()
+	d := NewDictionary(a, time.Hour)
+
+	countries, err := d.Countries(ctx)
+	if assert.NoError(t, err) && assert.Len(t, countries, 1) {
+		countries[0].IsoCode = "XX"
+	}
+	countries, _ = d.Countries(ctx)
+	assert.Equal(t, "US", countries[0].IsoCode)
+}
+
+func TestDictionaryConcurrentLookups(t *testing.T) {
+	release := make(chan struct{})
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/restapi/v1.0/dictionary/country" {
+			<-release
+		}
+		writeJSON(w, `{"records": [], "paging": {"page": 1, "totalPages": 1}}`)
+	})
+	defer close(release)
+	d := NewDictionary(a, 0)
+
+	go d.Countries(context.Background())
+	assert.Eventually(t, func() bool { return len(d.slot("countries").lock) == 1 }, time.Second, time.Millisecond)
+
+	// Other lists are not blocked by the slow country lookup
+	_, err := d.Timezones(context.Background())
+	assert.NoError(t, err)
+
+	// Waiting for the same list gives up when the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = d.Countries(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
@@ -0,0 +1,250 @@
+package ringcentral
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+// Event patterns used by the typed Dispatcher handlers when no pattern is
+// given. A * or ~ segment matches any account or extension id.
+const (
+	EventPatternAccountPresence            = "/restapi/v1.0/account/*/presence"
+	EventPatternExtensionPresence          = "/restapi/v1.0/account/*/extension/*/presence"
+	EventPatternDetailedPresence           = "/restapi/v1.0/account/*/extension/*/presence?detailedTelephonyState=true"
+	EventPatternMessageStore               = "/restapi/v1.0/account/*/extension/*/message-store"
+	EventPatternInstantMessage             = "/restapi/v1.0/account/*/extension/*/message-store/instant?type=SMS"
+	EventPatternTelephonySessions          = "/restapi/v1.0/account/*/telephony/sessions"
+	EventPatternExtensionTelephonySessions = "/restapi/v1.0/account/*/extension/*/telephony/sessions"
+)
+
+const apiPathPrefix = "/restapi/v1.0"
+
+// eventPattern is a parsed event filter pattern. Segments of * or ~ match any
+// single path segment. Every query parameter of the pattern must be present
+// in the event with the same value, or any value for *; other parameters of
+// the event are ignored.
+type eventPattern struct {
+	segments []string
+	query    url.Values
+}
+
+func parseEventPattern(s string) (eventPattern, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return eventPattern{}, fmt.Errorf("ringcentral: invalid event pattern %q: %v", s, err)
+	}
+	return eventPattern{segments: eventSegments(u.Path), query: u.Query()}, nil
+}
+
+func eventSegments(path string) []string {
+	path = strings.TrimPrefix(path, apiPathPrefix)
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func (p eventPattern) match(event string) bool {
+	u, err := url.Parse(event)
+	if err != nil {
+		return false
+	}
+	segments := eventSegments(u.Path)
+	if len(segments) != len(p.segments) {
+		return false
+	}
+	for i, s := range p.segments {
+		if s != "*" && s != "~" && s != segments[i] {
+			return false
+		}
+	}
+	q := u.Query()
+	for k, want := range p.query {
+		have, ok := q[k]
+		if !ok {
+			return false
+		}
+		if len(want) == 1 && want[0] == "*" {
+			continue
+		}
+		if !sameValues(want, have) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, v := range a {
+		found := false
+		for _, w := range b {
+			found = found || strings.EqualFold(v, w)
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+type route struct {
+	pattern eventPattern
+	handler NotificationHandler
+}
+
+// Dispatcher routes notifications to handlers by matching their event URI
+// against event filter patterns. Every matching handler is called, in the
+// order they were registered; notifications matching no handler go to the
+// fallback handler. Note a pattern without query parameters also matches the
+// events of filters with parameters, for example detailed presence events.
+//
+// A Dispatcher is a NotificationHandler, so it can be used with any transport.
+// It is safe for concurrent use.
+type Dispatcher struct {
+	mu       sync.RWMutex
+	routes   []route
+	fallback NotificationHandler
+}
+
+// NewDispatcher creates an empty dispatcher
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{}
+}
+
+// Handle registers a handler for the notifications matching pattern. It
+// panics if the pattern is invalid, like http.ServeMux.Handle.
+func (d *Dispatcher) Handle(pattern string, h NotificationHandler) {
+	p, err := parseEventPattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.routes = append(d.routes, route{pattern: p, handler: h})
+}
+
+// HandleFunc registers a handler function for the notifications matching pattern
+func (d *Dispatcher) HandleFunc(pattern string, f func(ctx context.Context, n *Notification) error) {
+	d.Handle(pattern, NotificationHandlerFunc(f))
+}
+
+// HandleUnknown sets the handler for notifications which match no pattern
+func (d *Dispatcher) HandleUnknown(h NotificationHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.fallback = h
+}
+
+func defaultPattern(pattern, def string) string {
+	if pattern == "" {
+		return def
+	}
+	return pattern
+}
+
+// HandleAccountPresence registers a handler for account presence events. An
+// empty pattern means EventPatternAccountPresence.
+func (d *Dispatcher) HandleAccountPresence(pattern string, f func(ctx context.Context, ev *AccountPresenceEvent) error) {
+	d.HandleFunc(defaultPattern(pattern, EventPatternAccountPresence), func(ctx context.Context, n *Notification) error {
+		var ev AccountPresenceEvent
+		if err := n.Decode(&ev); err != nil {
+			return err
+		}
+		return f(ctx, &ev)
+	})
+}
+
+// HandleExtensionPresence registers a handler for extension presence events.
+// An empty pattern means EventPatternExtensionPresence.
+func (d *Dispatcher) HandleExtensionPresence(pattern string, f func(ctx context.Context, ev *ExtensionPresenceEvent) error) {
+	d.HandleFunc(defaultPattern(pattern, EventPatternExtensionPresence), func(ctx context.Context, n *Notification) error {
+		var ev ExtensionPresenceEvent
+		if err := n.Decode(&ev); err != nil {
+			return err
+		}
+		return f(ctx, &ev)
+	})
+}
+
+// HandleDetailedPresence registers a handler for detailed extension presence
+// events. An empty pattern means EventPatternDetailedPresence.
+func (d *Dispatcher) HandleDetailedPresence(pattern string, f func(ctx context.Context, ev *DetailedExtensionPresenceEvent) error) {
+	d.HandleFunc(defaultPattern(pattern, EventPatternDetailedPresence), func(ctx context.Context, n *Notification) error {
+		var ev DetailedExtensionPresenceEvent
+		if err := n.Decode(&ev); err != nil {
+			return err
+		}
+		return f(ctx, &ev)
+	})
+}
+
+// HandleMessageStore registers a handler for message store events. An empty
+// pattern means EventPatternMessageStore.
+func (d *Dispatcher) HandleMessageStore(pattern string, f func(ctx context.Context, ev *MessageStoreEventPayload) error) {
+	d.HandleFunc(defaultPattern(pattern, EventPatternMessageStore), func(ctx context.Context, n *Notification) error {
+		var ev MessageStoreEventPayload
+		if err := n.Decode(&ev); err != nil {
+			return err
+		}
+		return f(ctx, &ev)
+	})
+}
+
+// HandleInstantMessage registers a handler for inbound SMS events. An empty
+// pattern means EventPatternInstantMessage.
+func (d *Dispatcher) HandleInstantMessage(pattern string, f func(ctx context.Context, ev *InboundMessageEvent) error) {
+	d.HandleFunc(defaultPattern(pattern, EventPatternInstantMessage), func(ctx context.Context, n *Notification) error {
+		var ev InboundMessageEvent
+		if err := n.Decode(&ev); err != nil {
+			return err
+		}
+		return f(ctx, &ev)
+	})
+}
+
+// HandleTelephonySession registers a handler for telephony session events. An
+// empty pattern means both EventPatternTelephonySessions and
+// EventPatternExtensionTelephonySessions.
+func (d *Dispatcher) HandleTelephonySession(pattern string, f func(ctx context.Context, ev *TelephonySessionEvent) error) {
+	h := NotificationHandlerFunc(func(ctx context.Context, n *Notification) error {
+		var ev TelephonySessionEvent
+		if err := n.Decode(&ev); err != nil {
+			return err
+		}
+		return f(ctx, &ev)
+	})
+	if pattern != "" {
+		d.Handle(pattern, h)
+		return
+	}
+	d.Handle(EventPatternTelephonySessions, h)
+	d.Handle(EventPatternExtensionTelephonySessions, h)
+}
+
+// HandleNotification calls the handlers matching the event of n. All of them
+// are called even if one fails; the first error is returned.
+func (d *Dispatcher) HandleNotification(ctx context.Context, n *Notification) error {
+	d.mu.RLock()
+	var handlers []NotificationHandler
+	for _, r := range d.routes {
+		if r.pattern.match(n.Event) {
+			handlers = append(handlers, r.handler)
+		}
+	}
+	if len(handlers) == 0 && d.fallback != nil {
+		handlers = append(handlers, d.fallback)
+	}
+	d.mu.RUnlock()
+
+	var firstErr error
+	for _, h := range handlers {
+		if err := h.HandleNotification(ctx, n); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
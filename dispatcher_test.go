@@ -0,0 +1,96 @@
+package ringcentral
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestEventPatternMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		event   string
+		match   bool
+	}{
+		{EventPatternExtensionPresence, "/restapi/v1.0/account/1/extension/2/presence", true},
+		{EventPatternExtensionPresence, "/restapi/v1.0/account/1/extension/2/presence?detailedTelephonyState=true", true},
+		{EventPatternDetailedPresence, "/restapi/v1.0/account/1/extension/2/presence", false},
+		{EventPatternDetailedPresence, "/restapi/v1.0/account/1/extension/2/presence?detailedTelephonyState=true&sipData=true", true},
+		{EventPatternAccountPresence, "/restapi/v1.0/account/1/extension/2/presence", false},
+		{"/restapi/v1.0/account/~/extension/2/presence", "/restapi/v1.0/account/1/extension/3/presence", false},
+		{"/account/*/extension/*/message-store/instant?type=*", "/restapi/v1.0/account/1/extension/2/message-store/instant?type=SMS", true},
+		{EventPatternInstantMessage, "/restapi/v1.0/account/1/extension/2/message-store/instant?type=Pager", false},
+		{EventPatternTelephonySessions, "/restapi/v1.0/account/1/telephony/sessions", true},
+		{EventPatternTelephonySessions, "/restapi/v1.0/account/1/extension/2/telephony/sessions", false},
+		{EventPatternExtensionTelephonySessions, "/restapi/v1.0/account/1/extension/2/telephony/sessions", true},
+	}
+	for _, tt := range tests {
+		p, err := parseEventPattern(tt.pattern)
+		if assert.NoError(t, err) {
+			assert.Equal(t, tt.match, p.match(tt.event), "%s ~ %s", tt.pattern, tt.event)
+		}
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	d := NewDispatcher()
+	var presence, detailed, unknown []string
+	d.HandleExtensionPresence("", func(ctx context.Context, ev *ExtensionPresenceEvent) error {
+		presence = append(presence, ev.Body.ExtensionID)
+		return nil
+	})
+	d.HandleDetailedPresence("", func(ctx context.Context, ev *DetailedExtensionPresenceEvent) error {
+		detailed = append(detailed, ev.Body.ActiveCalls[0].ID)
+		return nil
+	})
+	d.HandleUnknown(NotificationHandlerFunc(func(ctx context.Context, n *Notification) error {
+		unknown = append(unknown, n.Event)
+		return nil
+	}))
+
+	for _, payload := range []string{
+		`{"event": "/restapi/v1.0/account/1/extension/2/presence", "body": {"extensionId": "2"}}`,
+		`{"event": "/restapi/v1.0/account/1/extension/3/presence?detailedTelephonyState=true", "body": {"extensionId": "3", "activeCalls": [{"id": "c-1"}]}}`,
+		`{"event": "/restapi/v1.0/glip/posts", "body": {}}`,
+	} {
+		n, err := ParseNotification([]byte(payload))
+		if assert.NoError(t, err) {
+			assert.NoError(t, d.HandleNotification(context.Background(), n))
+		}
+	}
+	assert.Equal(t, []string{"2", "3"}, presence)
+	assert.Equal(t, []string{"c-1"}, detailed)
+	assert.Equal(t, []string{"/restapi/v1.0/glip/posts"}, unknown)
+}
+
+func TestDispatcherTelephonySessions(t *testing.T) {
+	d := NewDispatcher()
+	var sessions, unknown []string
+	d.HandleTelephonySession("", func(ctx context.Context, ev *TelephonySessionEvent) error {
+		sessions = append(sessions, ev.Body.TelephonySessionID)
+		return nil
+	})
+	d.HandleUnknown(NotificationHandlerFunc(func(ctx context.Context, n *Notification) error {
+		unknown = append(unknown, n.Event)
+		return nil
+	}))
+
+	for i, f := range []EventFilter{
+		EventFilter{}.TelephonySessions(),
+		EventFilter{}.Extension(101).TelephonySessions(),
+	} {
+		payload, _ := json.Marshal(map[string]interface{}{
+			"event": f.String(),
+			"body":  map[string]interface{}{"telephonySessionId": fmt.Sprintf("s-%d", i)},
+		})
+		n, err := ParseNotification(payload)
+		if assert.NoError(t, err) {
+			assert.NoError(t, d.HandleNotification(context.Background(), n))
+		}
+	}
+	assert.Equal(t, []string{"s-0", "s-1"}, sessions)
+	assert.Empty(t, unknown)
+}
@@ -0,0 +1,269 @@
+package ringcentral
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/context"
+)
+
+var (
+	// ErrInvalidEmergencyLocationID is returned when an operation requires an emergency location id,
+	// or is given a nil location
+	ErrInvalidEmergencyLocationID = errors.New("ringcentral: invalid emergency location id")
+)
+
+// EmergencyAddressStatus is the validation status of an emergency address
+type EmergencyAddressStatus string
+
+// Emergency address statuses
+const (
+	EmergencyAddressStatusValid        EmergencyAddressStatus = "Valid"
+	EmergencyAddressStatusInvalid      EmergencyAddressStatus = "Invalid"
+	EmergencyAddressStatusProvisioning EmergencyAddressStatus = "Provisioning"
+)
+
+// EmergencyLocationUsageStatus tells whether an emergency location can be assigned to devices
+type EmergencyLocationUsageStatus string
+
+// Emergency location usage statuses
+const (
+	EmergencyLocationActive   EmergencyLocationUsageStatus = "Active"
+	EmergencyLocationInactive EmergencyLocationUsageStatus = "Inactive"
+)
+
+// EmergencyLocationVisibility tells who can use an emergency location
+type EmergencyLocationVisibility string
+
+// Emergency location visibilities
+const (
+	EmergencyLocationPrivate EmergencyLocationVisibility = "Private"
+	EmergencyLocationPublic  EmergencyLocationVisibility = "Public"
+)
+
+// EmergencyLocation see https://developer.ringcentral.com/api-reference/Get-Emergency-Location
+type EmergencyLocation struct {
+	ID            string                       `json:"id,omitempty"`
+	Name          string                       `json:"name"`
+	Address       EmergencyAddressInfo         `json:"address"`
+	Site          *SiteInfo                    `json:"site,omitempty"`
+	AddressStatus EmergencyAddressStatus       `json:"addressStatus,omitempty"`
+	UsageStatus   EmergencyLocationUsageStatus `json:"usageStatus,omitempty"`
+	Visibility    EmergencyLocationVisibility  `json:"visibility,omitempty"`
+	SyncStatus    string                       `json:"syncStatus,omitempty"`
+}
+
+// EmergencyLocationList is a page of emergency locations
+type EmergencyLocationList struct {
+	Records    []EmergencyLocation `json:"records"`
+	Navigation Navigation          `json:"navigation"`
+	Paging     Paging              `json:"paging"`
+}
+
+// DeviceEmergencyInfo is the emergency address or location assigned to a device
+type DeviceEmergencyInfo struct {
+	Address       *EmergencyAddressInfo  `json:"address,omitempty"`
+	Location      *EmergencyLocationRef  `json:"location,omitempty"`
+	AddressStatus EmergencyAddressStatus `json:"addressStatus,omitempty"`
+	SyncStatus    string                 `json:"syncStatus,omitempty"`
+}
+
+// EmergencyLocationRef refers to an emergency location. Requests only need the ID.
+type EmergencyLocationRef struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// EmergencyAddressError lists the fields missing from an emergency address
+type EmergencyAddressError struct {
+	Missing []string
+}
+
+func (e *EmergencyAddressError) Error() string {
+	return "ringcentral: emergency address is missing " + strings.Join(e.Missing, ", ")
+}
+
+// ValidateEmergencyAddress checks that the address has the fields required to
+// register it with emergency services. US and Canadian addresses also need a state.
+// It returns an *EmergencyAddressError listing the missing fields.
+func ValidateEmergencyAddress(addr *EmergencyAddressInfo) error {
+	if addr == nil {
+		return &EmergencyAddressError{Missing: []string{"address"}}
+	}
+	var missing []string
+	if strings.TrimSpace(addr.Street) == "" {
+		missing = append(missing, "street")
+	}
+	if strings.TrimSpace(addr.City) == "" {
+		missing = append(missing, "city")
+	}
+	if strings.TrimSpace(addr.Zip) == "" {
+		missing = append(missing, "zip")
+	}
+	country := emergencyAddressCountry(addr)
+	if country == "" && addr.CountryID == "" && addr.Country == "" && addr.CountryName == "" {
+		missing = append(missing, "country")
+	}
+	if (country == "US" || country == "CA") && addr.State == "" && addr.StateID == "" && addr.StateIsoCode == "" {
+		missing = append(missing, "state")
+	}
+	if len(missing) > 0 {
+		return &EmergencyAddressError{Missing: missing}
+	}
+	return nil
+}
+
+// emergencyAddressCountry returns the ISO code of the country of the address,
+// if it is the US or Canada and only given by id or name
+func emergencyAddressCountry(addr *EmergencyAddressInfo) string {
+	if addr.CountryIsoCode != "" {
+		return strings.ToUpper(addr.CountryIsoCode)
+	}
+	switch addr.CountryID {
+	case "1":
+		return "US"
+	case "39":
+		return "CA"
+	}
+	for _, name := range []string{addr.Country, addr.CountryName} {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "us", "usa", "united states":
+			return "US"
+		case "ca", "canada":
+			return "CA"
+		}
+	}
+	return ""
+}
+
+// HasEmergencyAddress returns true if the device has a valid emergency address
+// or an emergency location assigned
+func (d *Device) HasEmergencyAddress() bool {
+	if d.Emergency != nil {
+		if d.Emergency.AddressStatus == EmergencyAddressStatusInvalid {
+			return false
+		}
+		if d.Emergency.Location != nil && d.Emergency.Location.ID != "" {
+			return true
+		}
+		if ValidateEmergencyAddress(d.Emergency.Address) == nil {
+			return true
+		}
+	}
+	return ValidateEmergencyAddress(d.EmergencyServiceAddress) == nil
+}
+
+// DevicesWithoutEmergencyAddress returns the devices, soft phones included,
+// which can't place emergency calls with a registered address
+func DevicesWithoutEmergencyAddress(devices []Device) []Device {
+	var missing []Device
+	for i := range devices {
+		if !devices[i].HasEmergencyAddress() {
+			missing = append(missing, devices[i])
+		}
+	}
+	return missing
+}
+
+func (a *API) emergencyLocationURL(id string) string {
+	urlStr := fmt.Sprintf("/restapi/v1.0/account/%s/emergency-locations", a.AccountID)
+	if id != "" {
+		urlStr += "/" + url.PathEscape(id)
+	}
+	return urlStr
+}
+
+// ListEmergencyLocations returns a page of the emergency locations of the account
+func (a *API) ListEmergencyLocations(ctx context.Context, params url.Values) (*EmergencyLocationList, error) {
+	var l EmergencyLocationList
+	if _, err := a.Get(ctx, a.emergencyLocationURL(""), params, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// AllEmergencyLocations fetches every page of the emergency locations of the account
+func (a *API) AllEmergencyLocations(ctx context.Context) ([]EmergencyLocation, error) {
+	var all []EmergencyLocation
+	for page := 1; ; page++ {
+		l, err := a.ListEmergencyLocations(ctx, url.Values{"page": []string{strconv.Itoa(page)}})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, l.Records...)
+		if !hasNextPage(len(l.Records), l.Paging, l.Navigation) {
+			return all, nil
+		}
+	}
+}
+
+// GetEmergencyLocation returns the given emergency location
+func (a *API) GetEmergencyLocation(ctx context.Context, id string) (*EmergencyLocation, error) {
+	if id == "" {
+		return nil, ErrInvalidEmergencyLocationID
+	}
+	var l EmergencyLocation
+	if _, err := a.Get(ctx, a.emergencyLocationURL(id), nil, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// CreateEmergencyLocation validates the address of the location and creates it
+func (a *API) CreateEmergencyLocation(ctx context.Context, loc *EmergencyLocation) (*EmergencyLocation, error) {
+	if loc == nil {
+		return nil, ErrInvalidEmergencyLocationID
+	}
+	if err := ValidateEmergencyAddress(&loc.Address); err != nil {
+		return nil, err
+	}
+	var l EmergencyLocation
+	if _, err := a.Post(ctx, a.emergencyLocationURL(""), loc, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// UpdateEmergencyLocation validates the address of the location and updates it
+func (a *API) UpdateEmergencyLocation(ctx context.Context, loc *EmergencyLocation) (*EmergencyLocation, error) {
+	if loc == nil || loc.ID == "" {
+		return nil, ErrInvalidEmergencyLocationID
+	}
+	if err := ValidateEmergencyAddress(&loc.Address); err != nil {
+		return nil, err
+	}
+	var l EmergencyLocation
+	if _, err := a.Put(ctx, a.emergencyLocationURL(loc.ID), loc, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// DeleteEmergencyLocation deletes the given emergency location
+func (a *API) DeleteEmergencyLocation(ctx context.Context, id string) error {
+	if id == "" {
+		return ErrInvalidEmergencyLocationID
+	}
+	_, err := a.Delete(ctx, a.emergencyLocationURL(id))
+	return err
+}
+
+// UpdateDeviceEmergencyAddress validates the address and sets it as the
+// emergency address of the given device
+func (a *API) UpdateDeviceEmergencyAddress(ctx context.Context, id string, addr *EmergencyAddressInfo) (*Device, error) {
+	if err := ValidateEmergencyAddress(addr); err != nil {
+		return nil, err
+	}
+	return a.UpdateDevice(ctx, id, &DeviceUpdateRequest{EmergencyServiceAddress: addr})
+}
+
+// UpdateDeviceEmergencyLocation assigns the given emergency location to the device
+func (a *API) UpdateDeviceEmergencyLocation(ctx context.Context, id, locationID string) (*Device, error) {
+	if locationID == "" {
+		return nil, ErrInvalidEmergencyLocationID
+	}
+	return a.UpdateDevice(ctx, id, &DeviceUpdateRequest{Emergency: &DeviceEmergencyInfo{Location: &EmergencyLocationRef{ID: locationID}}})
+}
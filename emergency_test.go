@@ -0,0 +1,85 @@
+package ringcentral
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestValidateEmergencyAddress(t *testing.T) {
+	err := ValidateEmergencyAddress(nil)
+	assert.Equal(t, []string{"address"}, err.(*EmergencyAddressError).Missing)
+
+	addr := &EmergencyAddressInfo{Street: "20 Davis Drive", City: "Belmont", Zip: "94002", CountryIsoCode: "US"}
+	err = ValidateEmergencyAddress(addr)
+	if assert.Error(t, err) {
+		assert.Equal(t, []string{"state"}, err.(*EmergencyAddressError).Missing)
+		assert.Equal(t, "ringcentral: emergency address is missing state", err.Error())
+	}
+
+	addr.StateIsoCode = "CA"
+	assert.NoError(t, ValidateEmergencyAddress(addr))
+
+	// The state is required when the country is only given by id or name
+	addr = &EmergencyAddressInfo{Street: "1 Front St", City: "Toronto", Zip: "M5J 2N8", CountryID: "39"}
+	assert.Equal(t, []string{"state"}, ValidateEmergencyAddress(addr).(*EmergencyAddressError).Missing)
+	addr = &EmergencyAddressInfo{Street: "20 Davis Drive", City: "Belmont", Zip: "94002", CountryName: "United States"}
+	assert.Equal(t, []string{"state"}, ValidateEmergencyAddress(addr).(*EmergencyAddressError).Missing)
+	addr.StateID = "16"
+	assert.NoError(t, ValidateEmergencyAddress(addr))
+
+	err = ValidateEmergencyAddress(&EmergencyAddressInfo{Street: " ", CountryIsoCode: "GB"})
+	assert.Equal(t, []string{"street", "city", "zip"}, err.(*EmergencyAddressError).Missing)
+}
+
+func TestDevicesWithoutEmergencyAddress(t *testing.T) {
+	valid := &EmergencyAddressInfo{Street: "1 Main St", City: "London", Zip: "N1", CountryIsoCode: "GB"}
+	devices := []Device{
+		{ID: "1", Type: DeviceTypeHardPhone, EmergencyServiceAddress: valid},
+		{ID: "2", Type: DeviceTypeHardPhone},
+		{ID: "3", Type: DeviceTypeSoftPhone},
+		{ID: "4", Type: DeviceTypeHardPhone, Emergency: &DeviceEmergencyInfo{Location: &EmergencyLocationRef{ID: "77"}}},
+		{ID: "5", Type: DeviceTypeOtherPhone, EmergencyServiceAddress: valid, Emergency: &DeviceEmergencyInfo{AddressStatus: EmergencyAddressStatusInvalid}},
+	}
+	var ids []string
+	for _, d := range DevicesWithoutEmergencyAddress(devices) {
+		ids = append(ids, d.ID)
+	}
+	assert.Equal(t, []string{"2", "3", "5"}, ids)
+}
+
+func TestUpdateDeviceEmergencyLocation(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/device/800130005", r.URL.Path)
+		var body map[string]interface{}
+		decodeBody(t, r, &body)
+		assert.Equal(t, map[string]interface{}{"emergency": map[string]interface{}{"location": map[string]interface{}{"id": "77"}}}, body)
+		writeJSON(w, `{"id": "800130005", "emergency": {"location": {"id": "77", "name": "HQ"}, "addressStatus": "Valid"}}`)
+	})
+
+	d, err := a.UpdateDeviceEmergencyLocation(context.Background(), "800130005", "77")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "HQ", d.Emergency.Location.Name)
+		assert.True(t, d.HasEmergencyAddress())
+	}
+}
+
+func TestEmergencyLocationRequests(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	ctx := context.Background()
+
+	// Invalid locations are rejected before sending a request
+	_, err := a.CreateEmergencyLocation(ctx, nil)
+	assert.Equal(t, ErrInvalidEmergencyLocationID, err)
+	_, err = a.CreateEmergencyLocation(ctx, &EmergencyLocation{Name: "HQ"})
+	assert.IsType(t, &EmergencyAddressError{}, err)
+	_, err = a.UpdateEmergencyLocation(ctx, nil)
+	assert.Equal(t, ErrInvalidEmergencyLocationID, err)
+	_, err = a.UpdateEmergencyLocation(ctx, &EmergencyLocation{Name: "HQ"})
+	assert.Equal(t, ErrInvalidEmergencyLocationID, err)
+}
@@ -0,0 +1,203 @@
+package ringcentral
+
+import (
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidEventFilter is returned by ParseEventFilter for strings which
+	// aren't account or extension event filters
+	ErrInvalidEventFilter = errors.New("ringcentral: invalid event filter")
+)
+
+// MessageType is the type of a message store message
+type MessageType string
+
+// Message types
+const (
+	MessageTypeSMS       MessageType = "SMS"
+	MessageTypePager     MessageType = "Pager"
+	MessageTypeFax       MessageType = "Fax"
+	MessageTypeVoiceMail MessageType = "VoiceMail"
+)
+
+// EventFilter builds the event filter strings of CreateSubscriptionRequest.
+// Start from the zero value, which refers to the current account:
+//
+//	EventFilter{}.ExtensionPresence(0).Detailed()
+//	EventFilter{}.Extension(101).InstantMessage()
+//	EventFilter{}.Extension(0).MessageStore(MessageTypeSMS)
+//	EventFilter{}.TelephonySessions()
+//
+// An id of 0 means the current account or extension, rendered as ~. The
+// methods return a copy, so a filter can be used as a template.
+type EventFilter struct {
+	AccountID   string
+	ExtensionID string
+	Resource    string
+	Query       url.Values
+}
+
+// Account returns the filter for the given account
+func (f EventFilter) Account(id int64) EventFilter {
+	f.AccountID = extensionID(id)
+	return f
+}
+
+// Extension returns the filter for the given extension
+func (f EventFilter) Extension(ext int64) EventFilter {
+	f.ExtensionID = extensionID(ext)
+	return f
+}
+
+// AccountPresence returns the filter for the presence of all the extensions of the account
+func (f EventFilter) AccountPresence() EventFilter {
+	f.ExtensionID = ""
+	f.Resource = "presence"
+	return f
+}
+
+// ExtensionPresence returns the filter for the presence of the given extension
+func (f EventFilter) ExtensionPresence(ext int64) EventFilter {
+	f = f.Extension(ext)
+	f.Resource = "presence"
+	return f
+}
+
+// InstantMessage returns the filter for the inbound SMS of the extension, or
+// of the current extension if none was set
+func (f EventFilter) InstantMessage() EventFilter {
+	f = f.withExtension()
+	f.Resource = "message-store/instant"
+	return f.Set("type", string(MessageTypeSMS))
+}
+
+// MessageStore returns the filter for the message store changes of the
+// extension, or of the current extension if none was set, optionally
+// limited to the given message types
+func (f EventFilter) MessageStore(types ...MessageType) EventFilter {
+	f = f.withExtension()
+	f.Resource = "message-store"
+	f.Query = cloneValues(f.Query)
+	f.Query.Del("type")
+	for _, t := range types {
+		f.Query.Add("type", string(t))
+	}
+	return f
+}
+
+// TelephonySessions returns the filter for the telephony sessions of the
+// extension, or of the whole account if no extension was set
+func (f EventFilter) TelephonySessions() EventFilter {
+	f.Resource = "telephony/sessions"
+	return f
+}
+
+// Detailed adds the active calls to presence events
+func (f EventFilter) Detailed() EventFilter {
+	return f.Set("detailedTelephonyState", "true")
+}
+
+// Aggregated makes presence events reflect all the devices of the extension
+func (f EventFilter) Aggregated() EventFilter {
+	return f.Set("aggregated", "true")
+}
+
+// SIPData adds SIP data to presence and telephony session events
+func (f EventFilter) SIPData() EventFilter {
+	return f.Set("sipData", "true")
+}
+
+// Set returns the filter with the given query parameter
+func (f EventFilter) Set(key, value string) EventFilter {
+	f.Query = cloneValues(f.Query)
+	f.Query.Set(key, value)
+	return f
+}
+
+func (f EventFilter) withExtension() EventFilter {
+	if f.ExtensionID == "" {
+		f.ExtensionID = "~"
+	}
+	return f
+}
+
+// String renders the filter. Query parameters are sorted, so equal filters
+// render the same string.
+func (f EventFilter) String() string {
+	account := f.AccountID
+	if account == "" {
+		account = "~"
+	}
+	s := apiPathPrefix + "/account/" + account
+	if f.ExtensionID != "" {
+		s += "/extension/" + f.ExtensionID
+	}
+	if f.Resource != "" {
+		s += "/" + strings.Trim(f.Resource, "/")
+	}
+	if len(f.Query) > 0 {
+		q := cloneValues(f.Query)
+		for _, v := range q {
+			sort.Strings(v)
+		}
+		s += "?" + q.Encode()
+	}
+	return s
+}
+
+// ParseEventFilter parses an account or extension event filter. The
+// /restapi/v1.0 prefix is optional.
+func ParseEventFilter(s string) (EventFilter, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return EventFilter{}, ErrInvalidEventFilter
+	}
+	segments := eventSegments(u.Path)
+	if len(segments) < 3 || segments[0] != "account" || !validFilterID(segments[1]) {
+		return EventFilter{}, ErrInvalidEventFilter
+	}
+	f := EventFilter{AccountID: segments[1]}
+	segments = segments[2:]
+	if segments[0] == "extension" {
+		if len(segments) < 3 || !validFilterID(segments[1]) {
+			return EventFilter{}, ErrInvalidEventFilter
+		}
+		f.ExtensionID = segments[1]
+		segments = segments[2:]
+	}
+	f.Resource = strings.Join(segments, "/")
+	if q := u.Query(); len(q) > 0 {
+		f.Query = q
+	}
+	return f, nil
+}
+
+func validFilterID(id string) bool {
+	if id == "~" {
+		return true
+	}
+	_, err := strconv.ParseInt(id, 10, 64)
+	return err == nil
+}
+
+// EventFilterStrings renders the given filters
+func EventFilterStrings(filters ...EventFilter) []string {
+	s := make([]string, len(filters))
+	for i, f := range filters {
+		s[i] = f.String()
+	}
+	return s
+}
+
+func cloneValues(v url.Values) url.Values {
+	c := make(url.Values, len(v))
+	for k, vv := range v {
+		c[k] = append([]string(nil), vv...)
+	}
+	return c
+}
@@ -0,0 +1,51 @@
+package ringcentral
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventFilterString(t *testing.T) {
+	tests := []struct {
+		filter EventFilter
+		want   string
+	}{
+		{EventFilter{}.ExtensionPresence(0).Detailed(), "/restapi/v1.0/account/~/extension/~/presence?detailedTelephonyState=true"},
+		{EventFilter{}.ExtensionPresence(101).SIPData().Detailed(), "/restapi/v1.0/account/~/extension/101/presence?detailedTelephonyState=true&sipData=true"},
+		{EventFilter{}.AccountPresence().Aggregated(), "/restapi/v1.0/account/~/presence?aggregated=true"},
+		{EventFilter{}.InstantMessage(), "/restapi/v1.0/account/~/extension/~/message-store/instant?type=SMS"},
+		{EventFilter{}.Account(5).Extension(7).MessageStore(MessageTypeSMS, MessageTypeFax), "/restapi/v1.0/account/5/extension/7/message-store?type=Fax&type=SMS"},
+		{EventFilter{}.MessageStore(), "/restapi/v1.0/account/~/extension/~/message-store"},
+		{EventFilter{}.TelephonySessions(), "/restapi/v1.0/account/~/telephony/sessions"},
+		{EventFilter{}.Extension(0).TelephonySessions(), "/restapi/v1.0/account/~/extension/~/telephony/sessions"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.filter.String())
+		parsed, err := ParseEventFilter(tt.want)
+		if assert.NoError(t, err, tt.want) {
+			assert.Equal(t, tt.want, parsed.String())
+		}
+	}
+}
+
+func TestEventFilterCopies(t *testing.T) {
+	base := EventFilter{}.ExtensionPresence(0)
+	detailed := base.Detailed()
+	assert.Equal(t, "/restapi/v1.0/account/~/extension/~/presence", base.String())
+	assert.Equal(t, "true", detailed.Query.Get("detailedTelephonyState"))
+	assert.Equal(t, []string{base.String(), detailed.String()}, EventFilterStrings(base, detailed))
+}
+
+func TestParseEventFilter(t *testing.T) {
+	f, err := ParseEventFilter("/account/~/extension/101/presence?detailedTelephonyState=true")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "~", f.AccountID)
+		assert.Equal(t, "101", f.ExtensionID)
+		assert.Equal(t, "presence", f.Resource)
+	}
+	for _, s := range []string{"", "/restapi/v1.0/glip/posts", "/restapi/v1.0/account/abc/presence", "/restapi/v1.0/account/~/extension/~"} {
+		_, err := ParseEventFilter(s)
+		assert.Equal(t, ErrInvalidEventFilter, err, s)
+	}
+}
@@ -0,0 +1,79 @@
+package ringcentral
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/net/context"
+)
+
+var (
+	// ErrInvalidExtensionID is returned when an operation requires an explicit extension id
+	ErrInvalidExtensionID = errors.New("ringcentral: invalid extension id")
+)
+
+// ExtensionCreateRequest is the request body for CreateExtension
+type ExtensionCreateRequest struct {
+	Contact          ContactInfo       `json:"contact"`
+	ExtensionNumber  string            `json:"extensionNumber,omitempty"`
+	Type             ExtensionType     `json:"type,omitempty"`
+	Status           ExtensionStatus   `json:"status,omitempty"`
+	Password         string            `json:"password,omitempty"`
+	IvrPin           string            `json:"ivrPin,omitempty"`
+	PartnerID        string            `json:"partnerId,omitempty"`
+	RegionalSettings *RegionalSettings `json:"regionalSettings,omitempty"`
+	SetupWizardState SetupWizardState  `json:"setupWizardState,omitempty"`
+	CallQueueInfo    *CallQueueInfo    `json:"callQueueInfo,omitempty"`
+}
+
+// ExtensionUpdateRequest is the request body for UpdateExtension. Only non-empty
+// fields are updated. The department of a user is set with Contact.Department.
+type ExtensionUpdateRequest struct {
+	Status           ExtensionStatus   `json:"status,omitempty"`
+	StatusInfo       *StatusInfo       `json:"statusInfo,omitempty"`
+	ExtensionNumber  string            `json:"extensionNumber,omitempty"`
+	Contact          *ContactInfo      `json:"contact,omitempty"`
+	RegionalSettings *RegionalSettings `json:"regionalSettings,omitempty"`
+	SetupWizardState SetupWizardState  `json:"setupWizardState,omitempty"`
+	PartnerID        string            `json:"partnerId,omitempty"`
+	IvrPin           string            `json:"ivrPin,omitempty"`
+	Password         string            `json:"password,omitempty"`
+	CallQueueInfo    *CallQueueInfo    `json:"callQueueInfo,omitempty"`
+}
+
+// GetExtension returns the details of the given extension. An id of 0 returns
+// the currently authorized extension.
+func (a *API) GetExtension(ctx context.Context, id int64) (*ExtensionInfo, error) {
+	var e ExtensionInfo
+	if _, err := a.Get(ctx, a.extensionURL(id, ""), nil, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// CreateExtension creates a new extension in the account
+func (a *API) CreateExtension(ctx context.Context, req *ExtensionCreateRequest) (*ExtensionInfo, error) {
+	var e ExtensionInfo
+	if _, err := a.Post(ctx, fmt.Sprintf("/restapi/v1.0/account/%s/extension", a.AccountID), req, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// UpdateExtension updates the contact, status, regional settings or department of the given extension
+func (a *API) UpdateExtension(ctx context.Context, id int64, req *ExtensionUpdateRequest) (*ExtensionInfo, error) {
+	var e ExtensionInfo
+	if _, err := a.Put(ctx, a.extensionURL(id, ""), req, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// DeleteExtension deletes the given extension
+func (a *API) DeleteExtension(ctx context.Context, id int64) error {
+	if id == 0 {
+		return ErrInvalidExtensionID
+	}
+	_, err := a.Delete(ctx, a.extensionURL(id, ""))
+	return err
+}
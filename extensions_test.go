@@ -0,0 +1,119 @@
+package ringcentral
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestContactInfoJSON(t *testing.T) {
+	b, err := json.Marshal(&ContactInfo{FirstName: "Ann"})
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"firstName":"Ann"}`, string(b))
+	}
+
+	// Pointers allow sending false and nested objects only when set
+	no := false
+	b, err = json.Marshal(&ContactInfo{
+		EmailAsLoginName: &no,
+		BusinessAddress:  &ContactAddressInfo{City: "Belmont"},
+		PronouncedName:   &PronouncedNameInfo{Type: VoiceNameTypeTextToSpeech, Text: "Ann"},
+	})
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{"emailAsLoginName":false,"businessAddress":{"city":"Belmont"},"pronouncedName":{"type":"TextToSpeech","text":"Ann"}}`, string(b))
+	}
+}
+
+func TestGetExtension(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/~", r.URL.Path)
+		writeJSON(w, `{
+			"id": 400131005,
+			"extensionNumber": "101",
+			"contact": {"firstName": "Ann", "lastName": "Lee", "email": "ann@example.com", "emailAsLoginName": true},
+			"status": "Enabled",
+			"type": "User"
+		}`)
+	})
+
+	e, err := a.GetExtension(context.Background(), 0)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(400131005), e.ID)
+		assert.Equal(t, "Ann", e.Contact.FirstName)
+		if assert.NotNil(t, e.Contact.EmailAsLoginName) {
+			assert.True(t, *e.Contact.EmailAsLoginName)
+		}
+		assert.Nil(t, e.Contact.BusinessAddress)
+		assert.Equal(t, ExtensionTypeUser, e.Type)
+	}
+}
+
+func TestCreateExtension(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/extension", r.URL.Path)
+		var body map[string]interface{}
+		decodeBody(t, r, &body)
+		assert.Equal(t, map[string]interface{}{
+			"contact":         map[string]interface{}{"firstName": "Ann", "lastName": "Lee", "email": "ann@example.com"},
+			"extensionNumber": "101",
+			"type":            "User",
+			"status":          "NotActivated",
+		}, body)
+		writeJSON(w, `{"id": 400131005, "extensionNumber": "101", "status": "NotActivated"}`)
+	})
+
+	e, err := a.CreateExtension(context.Background(), &ExtensionCreateRequest{
+		Contact:         ContactInfo{FirstName: "Ann", LastName: "Lee", Email: "ann@example.com"},
+		ExtensionNumber: "101",
+		Type:            ExtensionTypeUser,
+		Status:          ExtensionStatusNotActivated,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(400131005), e.ID)
+		assert.Equal(t, ExtensionStatusNotActivated, e.Status)
+	}
+}
+
+func TestUpdateExtension(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/400131005", r.URL.Path)
+		var body map[string]interface{}
+		decodeBody(t, r, &body)
+		assert.Equal(t, map[string]interface{}{
+			"status":  "Disabled",
+			"contact": map[string]interface{}{"department": "Sales", "emailAsLoginName": false},
+		}, body)
+		writeJSON(w, `{"id": 400131005, "status": "Disabled", "contact": {"department": "Sales", "emailAsLoginName": false}}`)
+	})
+
+	no := false
+	e, err := a.UpdateExtension(context.Background(), 400131005, &ExtensionUpdateRequest{
+		Status:  ExtensionStatusDisabled,
+		Contact: &ContactInfo{Department: "Sales", EmailAsLoginName: &no},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, ExtensionStatusDisabled, e.Status)
+		assert.Equal(t, "Sales", e.Contact.Department)
+	}
+}
+
+func TestDeleteExtension(t *testing.T) {
+	var deleted string
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		deleted = r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if assert.NoError(t, a.DeleteExtension(context.Background(), 400131005)) {
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/400131005", deleted)
+	}
+	// The current extension can't be deleted
+	assert.Equal(t, ErrInvalidExtensionID, a.DeleteExtension(context.Background(), 0))
+}
@@ -0,0 +1,203 @@
+package ringcentral
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+
+	"golang.org/x/net/context"
+)
+
+var (
+	// ErrInvalidForwardingNumberID is returned when an operation requires a forwarding number id,
+	// or is given a nil forwarding number
+	ErrInvalidForwardingNumberID = errors.New("ringcentral: invalid forwarding number id")
+)
+
+// ForwardingNumberLabel is the display label of a forwarding number
+type ForwardingNumberLabel string
+
+// Forwarding number labels
+const (
+	ForwardingLabelHome                ForwardingNumberLabel = "Home"
+	ForwardingLabelMobile              ForwardingNumberLabel = "Mobile"
+	ForwardingLabelWork                ForwardingNumberLabel = "Work"
+	ForwardingLabelOther               ForwardingNumberLabel = "Other"
+	ForwardingLabelBusinessMobilePhone ForwardingNumberLabel = "Business Mobile Phone"
+)
+
+// ForwardingNumberType is the kind of phone a forwarding number rings
+type ForwardingNumberType string
+
+// Forwarding number types
+const (
+	ForwardingTypeHome                ForwardingNumberType = "Home"
+	ForwardingTypeMobile              ForwardingNumberType = "Mobile"
+	ForwardingTypeWork                ForwardingNumberType = "Work"
+	ForwardingTypePhoneLine           ForwardingNumberType = "PhoneLine"
+	ForwardingTypeOutage              ForwardingNumberType = "Outage"
+	ForwardingTypeOther               ForwardingNumberType = "Other"
+	ForwardingTypeBusinessMobilePhone ForwardingNumberType = "BusinessMobilePhone"
+	ForwardingTypeExternalCarrier     ForwardingNumberType = "ExternalCarrier"
+	ForwardingTypeExtensionApps       ForwardingNumberType = "ExtensionApps"
+)
+
+// ForwardingNumberFeature is a feature a forwarding number can be used for
+type ForwardingNumberFeature string
+
+// Forwarding number features
+const (
+	ForwardingFeatureCallFlip       ForwardingNumberFeature = "CallFlip"
+	ForwardingFeatureCallForwarding ForwardingNumberFeature = "CallForwarding"
+)
+
+// ForwardingNumber see https://developer.ringcentral.com/api-reference/List-Forwarding-Numbers
+type ForwardingNumber struct {
+	ID          string                    `json:"id,omitempty"`
+	URI         string                    `json:"uri,omitempty"`
+	PhoneNumber string                    `json:"phoneNumber,omitempty"`
+	Label       ForwardingNumberLabel     `json:"label,omitempty"`
+	Type        ForwardingNumberType      `json:"type,omitempty"`
+	Features    []ForwardingNumberFeature `json:"features,omitempty"`
+	FlipNumber  string                    `json:"flipNumber,omitempty"`
+	Device      *DeviceInfo               `json:"device,omitempty"`
+}
+
+// HasFeature returns true if the number can be used for the given feature
+func (f *ForwardingNumber) HasFeature(feature ForwardingNumberFeature) bool {
+	for _, ff := range f.Features {
+		if ff == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// ForwardingNumberList is a page of forwarding numbers
+type ForwardingNumberList struct {
+	URI        string             `json:"uri"`
+	Records    []ForwardingNumber `json:"records"`
+	Navigation Navigation         `json:"navigation"`
+	Paging     Paging             `json:"paging"`
+}
+
+func (a *API) forwardingNumberURL(ext int64, id string) string {
+	path := "/forwarding-number"
+	if id != "" {
+		path += "/" + url.PathEscape(id)
+	}
+	return a.extensionURL(ext, path)
+}
+
+// ListForwardingNumbers returns a page of the forwarding numbers of the given extension
+func (a *API) ListForwardingNumbers(ctx context.Context, ext int64, params url.Values) (*ForwardingNumberList, error) {
+	var l ForwardingNumberList
+	if _, err := a.Get(ctx, a.forwardingNumberURL(ext, ""), params, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// AllForwardingNumbers fetches every page of the forwarding numbers of the given extension
+func (a *API) AllForwardingNumbers(ctx context.Context, ext int64) ([]ForwardingNumber, error) {
+	var all []ForwardingNumber
+	for page := 1; ; page++ {
+		l, err := a.ListForwardingNumbers(ctx, ext, url.Values{"page": []string{strconv.Itoa(page)}})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, l.Records...)
+		if !hasNextPage(len(l.Records), l.Paging, l.Navigation) {
+			return all, nil
+		}
+	}
+}
+
+// GetForwardingNumber returns the given forwarding number
+func (a *API) GetForwardingNumber(ctx context.Context, ext int64, id string) (*ForwardingNumber, error) {
+	if id == "" {
+		return nil, ErrInvalidForwardingNumberID
+	}
+	var f ForwardingNumber
+	if _, err := a.Get(ctx, a.forwardingNumberURL(ext, id), nil, &f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// CreateForwardingNumber adds a forwarding number to the given extension
+func (a *API) CreateForwardingNumber(ctx context.Context, ext int64, f *ForwardingNumber) (*ForwardingNumber, error) {
+	var created ForwardingNumber
+	if _, err := a.Post(ctx, a.forwardingNumberURL(ext, ""), f, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateForwardingNumber updates the given forwarding number
+func (a *API) UpdateForwardingNumber(ctx context.Context, ext int64, f *ForwardingNumber) (*ForwardingNumber, error) {
+	if f == nil || f.ID == "" {
+		return nil, ErrInvalidForwardingNumberID
+	}
+	var updated ForwardingNumber
+	if _, err := a.Put(ctx, a.forwardingNumberURL(ext, f.ID), f, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteForwardingNumber deletes the given forwarding number
+func (a *API) DeleteForwardingNumber(ctx context.Context, ext int64, id string) error {
+	if id == "" {
+		return ErrInvalidForwardingNumberID
+	}
+	_, err := a.Delete(ctx, a.forwardingNumberURL(ext, id))
+	return err
+}
+
+// EnsureForwardingNumbers makes sure the given extension has the wanted
+// forwarding numbers, matched by phone number. Missing numbers are created and
+// numbers with a different label or type are updated; other numbers are left
+// alone. It returns the resulting forwarding numbers of the extension.
+func (a *API) EnsureForwardingNumbers(ctx context.Context, ext int64, want []ForwardingNumber) ([]ForwardingNumber, error) {
+	have, err := a.AllForwardingNumbers(ctx, ext)
+	if err != nil {
+		return nil, err
+	}
+	create, update := diffForwardingNumbers(have, want)
+	for i := range create {
+		if _, err := a.CreateForwardingNumber(ctx, ext, &create[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range update {
+		if _, err := a.UpdateForwardingNumber(ctx, ext, &update[i]); err != nil {
+			return nil, err
+		}
+	}
+	if len(create) == 0 && len(update) == 0 {
+		return have, nil
+	}
+	return a.AllForwardingNumbers(ctx, ext)
+}
+
+// diffForwardingNumbers returns the wanted numbers missing from have, and the
+// updates needed for the ones whose label or type differ.
+func diffForwardingNumbers(have, want []ForwardingNumber) (create, update []ForwardingNumber) {
+	byNumber := make(map[string]ForwardingNumber, len(have))
+	for _, f := range have {
+		byNumber[f.PhoneNumber] = f
+	}
+	for _, w := range want {
+		h, ok := byNumber[w.PhoneNumber]
+		if !ok {
+			create = append(create, w)
+			continue
+		}
+		if (w.Label != "" && w.Label != h.Label) || (w.Type != "" && w.Type != h.Type) {
+			w.ID = h.ID
+			update = append(update, w)
+		}
+	}
+	return create, update
+}
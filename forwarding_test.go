@@ -0,0 +1,169 @@
+package ringcentral
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestDiffForwardingNumbers(t *testing.T) {
+	have := []ForwardingNumber{
+		{ID: "1", PhoneNumber: "+16505550100", Label: ForwardingLabelHome, Type: ForwardingTypeHome, Features: []ForwardingNumberFeature{ForwardingFeatureCallFlip}},
+		{ID: "2", PhoneNumber: "+16505550101", Label: ForwardingLabelWork, Type: ForwardingTypeWork},
+		{ID: "3", PhoneNumber: "+16505550102", Label: ForwardingLabelOther, Type: ForwardingTypeOther},
+	}
+	want := []ForwardingNumber{
+		{PhoneNumber: "+16505550100"},
+		{PhoneNumber: "+16505550101", Label: ForwardingLabelMobile, Type: ForwardingTypeMobile},
+		{PhoneNumber: "+16505550199", Label: ForwardingLabelMobile, Type: ForwardingTypeMobile},
+	}
+	create, update := diffForwardingNumbers(have, want)
+	if assert.Len(t, create, 1) {
+		assert.Equal(t, "+16505550199", create[0].PhoneNumber)
+	}
+	if assert.Len(t, update, 1) {
+		assert.Equal(t, "2", update[0].ID)
+		assert.Equal(t, ForwardingTypeMobile, update[0].Type)
+	}
+	assert.True(t, have[0].HasFeature(ForwardingFeatureCallFlip))
+	assert.False(t, have[0].HasFeature(ForwardingFeatureCallForwarding))
+}
+
+func TestListForwardingNumbers(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/101/forwarding-number", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("page"))
+		writeJSON(w, `{"records": [{"id": "1", "phoneNumber": "+16505550100", "label": "Home", "features": ["CallFlip", "CallForwarding"], "flipNumber": "1"}], "paging": {"page": 2, "totalPages": 2}}`)
+	})
+
+	l, err := a.ListForwardingNumbers(context.Background(), 101, url.Values{"page": []string{"2"}})
+	if assert.NoError(t, err) && assert.Len(t, l.Records, 1) {
+		assert.Equal(t, ForwardingLabelHome, l.Records[0].Label)
+		assert.True(t, l.Records[0].HasFeature(ForwardingFeatureCallForwarding))
+		assert.Equal(t, 2, l.Paging.TotalPages)
+	}
+}
+
+func TestGetForwardingNumber(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/~/forwarding-number/1", r.URL.Path)
+		writeJSON(w, `{"id": "1", "phoneNumber": "+16505550100", "type": "Mobile"}`)
+	})
+
+	f, err := a.GetForwardingNumber(context.Background(), 0, "1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, ForwardingTypeMobile, f.Type)
+	}
+	_, err = a.GetForwardingNumber(context.Background(), 0, "")
+	assert.Equal(t, ErrInvalidForwardingNumberID, err)
+}
+
+func TestCreateForwardingNumber(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/101/forwarding-number", r.URL.Path)
+		var body map[string]interface{}
+		decodeBody(t, r, &body)
+		assert.Equal(t, map[string]interface{}{"phoneNumber": "+16505550100", "label": "Mobile", "type": "Mobile"}, body)
+		writeJSON(w, `{"id": "7", "phoneNumber": "+16505550100", "label": "Mobile", "type": "Mobile"}`)
+	})
+
+	f, err := a.CreateForwardingNumber(context.Background(), 101, &ForwardingNumber{PhoneNumber: "+16505550100", Label: ForwardingLabelMobile, Type: ForwardingTypeMobile})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "7", f.ID)
+	}
+}
+
+func TestUpdateForwardingNumber(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/101/forwarding-number/7", r.URL.Path)
+		var body map[string]interface{}
+		decodeBody(t, r, &body)
+		assert.Equal(t, map[string]interface{}{"id": "7", "label": "Work"}, body)
+		writeJSON(w, `{"id": "7", "phoneNumber": "+16505550100", "label": "Work"}`)
+	})
+
+	f, err := a.UpdateForwardingNumber(context.Background(), 101, &ForwardingNumber{ID: "7", Label: ForwardingLabelWork})
+	if assert.NoError(t, err) {
+		assert.Equal(t, ForwardingLabelWork, f.Label)
+	}
+	_, err = a.UpdateForwardingNumber(context.Background(), 101, &ForwardingNumber{Label: ForwardingLabelWork})
+	assert.Equal(t, ErrInvalidForwardingNumberID, err)
+	_, err = a.UpdateForwardingNumber(context.Background(), 101, nil)
+	assert.Equal(t, ErrInvalidForwardingNumberID, err)
+}
+
+func TestDeleteForwardingNumber(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/101/forwarding-number/7", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	assert.NoError(t, a.DeleteForwardingNumber(context.Background(), 101, "7"))
+	assert.Equal(t, ErrInvalidForwardingNumberID, a.DeleteForwardingNumber(context.Background(), 101, ""))
+}
+
+func TestAllForwardingNumbers(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		writeJSON(w, fmt.Sprintf(`{"records": [{"id": "%s"}], "paging": {"page": %s, "totalPages": 3}}`, page, page))
+	})
+
+	all, err := a.AllForwardingNumbers(context.Background(), 101)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []ForwardingNumber{{ID: "1"}, {ID: "2"}, {ID: "3"}}, all)
+	}
+}
+
+func TestEnsureForwardingNumbers(t *testing.T) {
+	var requests []string
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, `{"records": [
+				{"id": "1", "phoneNumber": "+16505550100", "label": "Home", "type": "Home"},
+				{"id": "2", "phoneNumber": "+16505550101", "label": "Work", "type": "Work"},
+				{"id": "3", "phoneNumber": "+16505550102", "label": "Other", "type": "Other"}
+			], "paging": {"page": 1, "totalPages": 1}}`)
+		case http.MethodPost:
+			var body ForwardingNumber
+			decodeBody(t, r, &body)
+			assert.Equal(t, "+16505550199", body.PhoneNumber)
+			writeJSON(w, `{"id": "4", "phoneNumber": "+16505550199"}`)
+		case http.MethodPut:
+			var body ForwardingNumber
+			decodeBody(t, r, &body)
+			assert.Equal(t, ForwardingLabelMobile, body.Label)
+			writeJSON(w, `{"id": "2", "phoneNumber": "+16505550101", "label": "Mobile"}`)
+		}
+	})
+
+	_, err := a.EnsureForwardingNumbers(context.Background(), 101, []ForwardingNumber{
+		{PhoneNumber: "+16505550100", Label: ForwardingLabelHome},
+		{PhoneNumber: "+16505550101", Label: ForwardingLabelMobile},
+		{PhoneNumber: "+16505550199", Label: ForwardingLabelMobile},
+	})
+	assert.NoError(t, err)
+	// The missing number is created, the changed one updated and the unwanted one left alone
+	assert.Equal(t, []string{
+		"GET /restapi/v1.0/account/~/extension/101/forwarding-number",
+		"POST /restapi/v1.0/account/~/extension/101/forwarding-number",
+		"PUT /restapi/v1.0/account/~/extension/101/forwarding-number/2",
+		"GET /restapi/v1.0/account/~/extension/101/forwarding-number",
+	}, requests)
+
+	// Nothing is changed when the numbers are as wanted
+	requests = nil
+	_, err = a.EnsureForwardingNumbers(context.Background(), 101, []ForwardingNumber{{PhoneNumber: "+16505550100", Label: ForwardingLabelHome}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GET /restapi/v1.0/account/~/extension/101/forwarding-number"}, requests)
+}
@@ -0,0 +1,264 @@
+package ringcentral
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+
+	"golang.org/x/net/context"
+)
+
+var (
+	// ErrInvalidIVRMenuID is returned when an operation requires an IVR menu id,
+	// or is given a nil menu
+	ErrInvalidIVRMenuID = errors.New("ringcentral: invalid ivr menu id")
+	// ErrInvalidIVRPromptID is returned when an operation requires an IVR prompt id
+	ErrInvalidIVRPromptID = errors.New("ringcentral: invalid ivr prompt id")
+)
+
+// IVRPromptMode is how an IVR menu prompt is played
+type IVRPromptMode string
+
+// IVR prompt modes
+const (
+	IVRPromptModeAudio        IVRPromptMode = "Audio"
+	IVRPromptModeTextToSpeech IVRPromptMode = "TextToSpeech"
+)
+
+// IVRAction is what happens when a caller presses a key in an IVR menu
+type IVRAction string
+
+// IVR actions
+const (
+	IVRActionConnect           IVRAction = "Connect"
+	IVRActionVoicemail         IVRAction = "Voicemail"
+	IVRActionDialByName        IVRAction = "DialByName"
+	IVRActionTransfer          IVRAction = "Transfer"
+	IVRActionRepeat            IVRAction = "Repeat"
+	IVRActionReturnToRoot      IVRAction = "ReturnToRoot"
+	IVRActionReturnToPrevious  IVRAction = "ReturnToPrevious"
+	IVRActionDisconnect        IVRAction = "Disconnect"
+	IVRActionConnectToOperator IVRAction = "ConnectToOperator"
+)
+
+// IVR inputs other than the digits 0-9
+const (
+	IVRInputStar    = "Star"
+	IVRInputHash    = "Hash"
+	IVRInputNoInput = "NoInput"
+)
+
+// IVRMenuInfo see https://developer.ringcentral.com/api-reference/Get-IVR-Menu
+type IVRMenuInfo struct {
+	ID              string              `json:"id,omitempty"`
+	URI             string              `json:"uri,omitempty"`
+	Name            string              `json:"name"`
+	ExtensionNumber string              `json:"extensionNumber,omitempty"`
+	Site            *SiteInfo           `json:"site,omitempty"`
+	Prompt          *IVRMenuPromptInfo  `json:"prompt,omitempty"`
+	Actions         []IVRMenuActionInfo `json:"actions,omitempty"`
+}
+
+// IVRMenuList is a page of IVR menus
+type IVRMenuList struct {
+	URI        string        `json:"uri"`
+	Records    []IVRMenuInfo `json:"records"`
+	Navigation Navigation    `json:"navigation"`
+	Paging     Paging        `json:"paging"`
+}
+
+// IVRMenuPromptInfo is the prompt played when a caller enters an IVR menu
+type IVRMenuPromptInfo struct {
+	Mode     IVRPromptMode `json:"mode"`
+	Text     string        `json:"text,omitempty"`
+	Audio    *URIInfo      `json:"audio,omitempty"`
+	Language *URIInfo      `json:"language,omitempty"`
+}
+
+// IVRMenuActionInfo maps a key press to an action
+type IVRMenuActionInfo struct {
+	Input       string                `json:"input"`
+	Action      IVRAction             `json:"action"`
+	Extension   *IVRMenuExtensionInfo `json:"extension,omitempty"`
+	PhoneNumber string                `json:"phoneNumber,omitempty"`
+}
+
+// IVRMenuExtensionInfo is the extension an IVR action connects to
+type IVRMenuExtensionInfo struct {
+	ID   string `json:"id"`
+	URI  string `json:"uri,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// IVRPrompt is an audio file which can be played by IVR menus
+type IVRPrompt struct {
+	ID          string `json:"id"`
+	URI         string `json:"uri"`
+	ContentURI  string `json:"contentUri"`
+	ContentType string `json:"contentType"`
+	Filename    string `json:"filename"`
+	Name        string `json:"name"`
+}
+
+// IVRPromptList is a page of IVR prompts
+type IVRPromptList struct {
+	URI        string      `json:"uri"`
+	Records    []IVRPrompt `json:"records"`
+	Navigation Navigation  `json:"navigation"`
+	Paging     Paging      `json:"paging"`
+}
+
+func (a *API) ivrMenuURL(id string) string {
+	urlStr := fmt.Sprintf("/restapi/v1.0/account/%s/ivr-menus", a.AccountID)
+	if id != "" {
+		urlStr += "/" + url.PathEscape(id)
+	}
+	return urlStr
+}
+
+func (a *API) ivrPromptURL(id string) string {
+	urlStr := fmt.Sprintf("/restapi/v1.0/account/%s/ivr-prompts", a.AccountID)
+	if id != "" {
+		urlStr += "/" + url.PathEscape(id)
+	}
+	return urlStr
+}
+
+// ListIVRMenus returns a page of the IVR menus of the account
+func (a *API) ListIVRMenus(ctx context.Context, params url.Values) (*IVRMenuList, error) {
+	var l IVRMenuList
+	if _, err := a.Get(ctx, a.ivrMenuURL(""), params, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// GetIVRMenu returns the given IVR menu
+func (a *API) GetIVRMenu(ctx context.Context, id string) (*IVRMenuInfo, error) {
+	if id == "" {
+		return nil, ErrInvalidIVRMenuID
+	}
+	var m IVRMenuInfo
+	if _, err := a.Get(ctx, a.ivrMenuURL(id), nil, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// CreateIVRMenu creates a new IVR menu
+func (a *API) CreateIVRMenu(ctx context.Context, menu *IVRMenuInfo) (*IVRMenuInfo, error) {
+	var m IVRMenuInfo
+	if _, err := a.Post(ctx, a.ivrMenuURL(""), menu, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// UpdateIVRMenu updates the IVR menu identified by menu.ID
+func (a *API) UpdateIVRMenu(ctx context.Context, menu *IVRMenuInfo) (*IVRMenuInfo, error) {
+	if menu == nil || menu.ID == "" {
+		return nil, ErrInvalidIVRMenuID
+	}
+	var m IVRMenuInfo
+	if _, err := a.Put(ctx, a.ivrMenuURL(menu.ID), menu, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// ListIVRPrompts returns the IVR prompts of the account
+func (a *API) ListIVRPrompts(ctx context.Context, params url.Values) (*IVRPromptList, error) {
+	var l IVRPromptList
+	if _, err := a.Get(ctx, a.ivrPromptURL(""), params, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// GetIVRPrompt returns the given IVR prompt
+func (a *API) GetIVRPrompt(ctx context.Context, id string) (*IVRPrompt, error) {
+	if id == "" {
+		return nil, ErrInvalidIVRPromptID
+	}
+	var p IVRPrompt
+	if _, err := a.Get(ctx, a.ivrPromptURL(id), nil, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// UploadIVRPrompt uploads an audio file as a new IVR prompt. contentType is
+// the type of the audio, for example "audio/mpeg" or "audio/wav".
+func (a *API) UploadIVRPrompt(ctx context.Context, name, filename, contentType string, audio io.Reader) (*IVRPrompt, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{"name": "attachment", "filename": filename}))
+	h.Set("Content-Type", contentType)
+	part, err := w.CreatePart(h)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, audio); err != nil {
+		return nil, err
+	}
+	if name != "" {
+		if err := w.WriteField("name", name); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, a.makeURL(a.ivrPromptURL(""), nil), &buf)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	var p IVRPrompt
+	if _, err := a.doRequest(ctx, req, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// RenameIVRPrompt changes the name of the given IVR prompt
+func (a *API) RenameIVRPrompt(ctx context.Context, id, name string) (*IVRPrompt, error) {
+	if id == "" {
+		return nil, ErrInvalidIVRPromptID
+	}
+	var p IVRPrompt
+	if _, err := a.Put(ctx, a.ivrPromptURL(id), map[string]string{"name": name}, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// DownloadIVRPrompt returns the audio content of the given IVR prompt. The
+// caller must close the returned reader.
+func (a *API) DownloadIVRPrompt(ctx context.Context, id string) (io.ReadCloser, string, error) {
+	if id == "" {
+		return nil, "", ErrInvalidIVRPromptID
+	}
+	resp, err := a.Get(ctx, a.ivrPromptURL(id)+"/content", nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Body, resp.Header.Get("Content-Type"), nil
+}
+
+// DeleteIVRPrompt deletes the given IVR prompt
+func (a *API) DeleteIVRPrompt(ctx context.Context, id string) error {
+	if id == "" {
+		return ErrInvalidIVRPromptID
+	}
+	_, err := a.Delete(ctx, a.ivrPromptURL(id))
+	return err
+}
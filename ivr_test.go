@@ -0,0 +1,126 @@
+package ringcentral
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestIVRMenuDecode(t *testing.T) {
+	data := `{
+		"id": "1000",
+		"name": "Main menu",
+		"extensionNumber": "500",
+		"prompt": {"mode": "TextToSpeech", "text": "Press 1 for sales"},
+		"actions": [
+			{"input": "1", "action": "Connect", "extension": {"id": "400131005"}},
+			{"input": "Star", "action": "Repeat"}
+		]
+	}`
+	var m IVRMenuInfo
+	if !assert.NoError(t, json.Unmarshal([]byte(data), &m)) {
+		return
+	}
+	assert.Equal(t, IVRPromptModeTextToSpeech, m.Prompt.Mode)
+	if assert.Len(t, m.Actions, 2) {
+		assert.Equal(t, IVRActionConnect, m.Actions[0].Action)
+		assert.Equal(t, "400131005", m.Actions[0].Extension.ID)
+		assert.Equal(t, IVRInputStar, m.Actions[1].Input)
+	}
+}
+
+func TestListIVRMenus(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/ivr-menus", r.URL.Path)
+		assert.Equal(t, "perPage=10", r.URL.RawQuery)
+		writeJSON(w, `{"records": [{"id": "1000", "name": "Main menu", "extensionNumber": "500"}], "paging": {"page": 1, "totalPages": 1}}`)
+	})
+
+	params := url.Values{"perPage": {"10"}}
+	l, err := a.ListIVRMenus(context.Background(), params)
+	if assert.NoError(t, err) && assert.Len(t, l.Records, 1) {
+		assert.Equal(t, "500", l.Records[0].ExtensionNumber)
+	}
+	// The params of the caller are left unchanged
+	assert.Len(t, params, 1)
+}
+
+func TestUpdateIVRMenu(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/ivr-menus/1000", r.URL.Path)
+		var m IVRMenuInfo
+		decodeBody(t, r, &m)
+		assert.Equal(t, "Main menu", m.Name)
+		if assert.Len(t, m.Actions, 1) {
+			assert.Equal(t, IVRActionTransfer, m.Actions[0].Action)
+			assert.Equal(t, "+16505550100", m.Actions[0].PhoneNumber)
+		}
+		writeJSON(w, `{"id": "1000", "name": "Main menu", "actions": [{"input": "0", "action": "Transfer", "phoneNumber": "+16505550100"}]}`)
+	})
+
+	m, err := a.UpdateIVRMenu(context.Background(), &IVRMenuInfo{
+		ID:      "1000",
+		Name:    "Main menu",
+		Actions: []IVRMenuActionInfo{{Input: "0", Action: IVRActionTransfer, PhoneNumber: "+16505550100"}},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1000", m.ID)
+	}
+	_, err = a.UpdateIVRMenu(context.Background(), &IVRMenuInfo{Name: "Main menu"})
+	assert.Equal(t, ErrInvalidIVRMenuID, err)
+	_, err = a.UpdateIVRMenu(context.Background(), nil)
+	assert.Equal(t, ErrInvalidIVRMenuID, err)
+}
+
+func TestUploadIVRPrompt(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/ivr-prompts", r.URL.Path)
+		if !assert.NoError(t, r.ParseMultipartForm(1<<20)) {
+			return
+		}
+		assert.Equal(t, []string{"Welcome"}, r.MultipartForm.Value["name"])
+		files := r.MultipartForm.File["attachment"]
+		if assert.Len(t, files, 1) {
+			assert.Equal(t, `welcome "v2".mp3`, files[0].Filename)
+			assert.Equal(t, "audio/mpeg", files[0].Header.Get("Content-Type"))
+			f, err := files[0].Open()
+			if assert.NoError(t, err) {
+				data, _ := ioutil.ReadAll(f)
+				assert.Equal(t, "ID3 audio", string(data))
+			}
+		}
+		writeJSON(w, `{"id": "7", "name": "Welcome", "filename": "welcome \"v2\".mp3", "contentType": "audio/mpeg"}`)
+	})
+
+	p, err := a.UploadIVRPrompt(context.Background(), "Welcome", `welcome "v2".mp3`, "audio/mpeg", strings.NewReader("ID3 audio"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "7", p.ID)
+		assert.Equal(t, "audio/mpeg", p.ContentType)
+	}
+}
+
+func TestDownloadIVRPrompt(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/restapi/v1.0/account/~/ivr-prompts/7/content", r.URL.Path)
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Write([]byte("ID3 audio"))
+	})
+
+	body, contentType, err := a.DownloadIVRPrompt(context.Background(), "7")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer body.Close()
+	data, _ := ioutil.ReadAll(body)
+	assert.Equal(t, "ID3 audio", string(data))
+	assert.Equal(t, "audio/mpeg", contentType)
+}
@@ -1,6 +1,12 @@
 package ringcentral
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+)
 
 type MessageStoreEventPayload struct {
 	Timestamp      time.Time `json:"timestamp"`
@@ -157,10 +163,53 @@ type PresenceLineEvent struct {
 }
 
 type ActiveCall struct {
-	ID              string          `json:"id"`
-	Direction       Direction       `json:"direction"`
-	From            string          `json:"from"`
-	To              string          `json:"to"`
-	TelephonyStatus TelephonyStatus `json:"telephonyStatus"`
-	SessionID       string          `json:"sessionId"`
+	ID                 string          `json:"id"`
+	Direction          Direction       `json:"direction"`
+	From               string          `json:"from"`
+	To                 string          `json:"to"`
+	TelephonyStatus    TelephonyStatus `json:"telephonyStatus"`
+	SessionID          string          `json:"sessionId"`
+	TelephonySessionID string          `json:"telephonySessionId,omitempty"`
+	PartyID            string          `json:"partyId,omitempty"`
+	StartTime          *time.Time      `json:"startTime,omitempty"`
+}
+
+// Notification is a notification as delivered by any transport. Body holds
+// the raw event body; Raw holds the whole payload so it can be decoded into
+// one of the typed events, such as InboundMessageEvent.
+type Notification struct {
+	UUID           string          `json:"uuid"`
+	Event          string          `json:"event"`
+	SubscriptionID string          `json:"subscriptionId"`
+	Timestamp      time.Time       `json:"timestamp"`
+	Body           json.RawMessage `json:"body"`
+	Raw            []byte          `json:"-"`
+}
+
+// ParseNotification decodes a notification payload
+func ParseNotification(data []byte) (*Notification, error) {
+	var n Notification
+	if err := json.Unmarshal(data, &n); err != nil {
+		return nil, fmt.Errorf("ringcentral: error decoding notification: %v", err)
+	}
+	n.Raw = data
+	return &n, nil
+}
+
+// Decode decodes the whole notification payload into dstVal
+func (n *Notification) Decode(dstVal interface{}) error {
+	return json.Unmarshal(n.Raw, dstVal)
+}
+
+// NotificationHandler handles notifications received by a transport
+type NotificationHandler interface {
+	HandleNotification(ctx context.Context, n *Notification) error
+}
+
+// NotificationHandlerFunc adapts a function to a NotificationHandler
+type NotificationHandlerFunc func(ctx context.Context, n *Notification) error
+
+// HandleNotification calls f(ctx, n)
+func (f NotificationHandlerFunc) HandleNotification(ctx context.Context, n *Notification) error {
+	return f(ctx, n)
 }
@@ -0,0 +1,181 @@
+package ringcentral
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"golang.org/x/net/context"
+)
+
+// PhoneNumberUsageType is how a phone number is used in the account
+type PhoneNumberUsageType string
+
+// Phone number usage types
+const (
+	PhoneNumberUsageMainCompanyNumber       PhoneNumberUsageType = "MainCompanyNumber"
+	PhoneNumberUsageAdditionalCompanyNumber PhoneNumberUsageType = "AdditionalCompanyNumber"
+	PhoneNumberUsageCompanyNumber           PhoneNumberUsageType = "CompanyNumber"
+	PhoneNumberUsageDirectNumber            PhoneNumberUsageType = "DirectNumber"
+	PhoneNumberUsageCompanyFaxNumber        PhoneNumberUsageType = "CompanyFaxNumber"
+	PhoneNumberUsageForwardedNumber         PhoneNumberUsageType = "ForwardedNumber"
+	PhoneNumberUsageForwardedCompanyNumber  PhoneNumberUsageType = "ForwardedCompanyNumber"
+	PhoneNumberUsageContactCenterNumber     PhoneNumberUsageType = "ContactCenterNumber"
+	PhoneNumberUsageConferencingNumber      PhoneNumberUsageType = "ConferencingNumber"
+	PhoneNumberUsageNumberPool              PhoneNumberUsageType = "NumberPool"
+	PhoneNumberUsageBusinessMobileNumber    PhoneNumberUsageType = "BusinessMobileNumber"
+)
+
+// PhoneNumberType is the kind of traffic a phone number supports
+type PhoneNumberType string
+
+// Phone number types
+const (
+	PhoneNumberTypeVoiceFax  PhoneNumberType = "VoiceFax"
+	PhoneNumberTypeFaxOnly   PhoneNumberType = "FaxOnly"
+	PhoneNumberTypeVoiceOnly PhoneNumberType = "VoiceOnly"
+)
+
+// PhoneNumberStatus is the provisioning status of a phone number
+type PhoneNumberStatus string
+
+// Phone number statuses
+const (
+	PhoneNumberStatusNormal    PhoneNumberStatus = "Normal"
+	PhoneNumberStatusPending   PhoneNumberStatus = "Pending"
+	PhoneNumberStatusPortedIn  PhoneNumberStatus = "PortedIn"
+	PhoneNumberStatusTemporary PhoneNumberStatus = "Temporary"
+)
+
+// PhoneNumberFeature is a capability of a phone number
+type PhoneNumberFeature string
+
+// Phone number features
+const (
+	PhoneNumberFeatureCallerID               PhoneNumberFeature = "CallerId"
+	PhoneNumberFeatureSmsSender              PhoneNumberFeature = "SmsSender"
+	PhoneNumberFeatureMmsSender              PhoneNumberFeature = "MmsSender"
+	PhoneNumberFeatureA2PSmsSender           PhoneNumberFeature = "A2PSmsSender"
+	PhoneNumberFeatureInternationalSmsSender PhoneNumberFeature = "InternationalSmsSender"
+	PhoneNumberFeatureDelegated              PhoneNumberFeature = "Delegated"
+)
+
+// PhoneNumberInfo see https://developer.ringcentral.com/api-reference/List-Company-Phone-Numbers
+type PhoneNumberInfo struct {
+	ID          int64                `json:"id"`
+	URI         string               `json:"uri"`
+	PhoneNumber string               `json:"phoneNumber"`
+	Label       string               `json:"label,omitempty"`
+	Location    string               `json:"location,omitempty"`
+	PaymentType string               `json:"paymentType,omitempty"`
+	Type        PhoneNumberType      `json:"type"`
+	UsageType   PhoneNumberUsageType `json:"usageType"`
+	Status      PhoneNumberStatus    `json:"status,omitempty"`
+	Primary     bool                 `json:"primary,omitempty"`
+	Country     CountryInfo          `json:"country"`
+	Extension   *ExtensionRef        `json:"extension,omitempty"`
+	Features    []PhoneNumberFeature `json:"features"`
+}
+
+// HasFeature returns true if the number has the given feature
+func (p *PhoneNumberInfo) HasFeature(f PhoneNumberFeature) bool {
+	for _, feature := range p.Features {
+		if feature == f {
+			return true
+		}
+	}
+	return false
+}
+
+// PhoneNumberList is a page of phone numbers
+type PhoneNumberList struct {
+	URI        string            `json:"uri"`
+	Records    []PhoneNumberInfo `json:"records"`
+	Navigation Navigation        `json:"navigation"`
+	Paging     Paging            `json:"paging"`
+}
+
+// FilterPhoneNumbers returns the numbers which have all the given features
+func FilterPhoneNumbers(numbers []PhoneNumberInfo, features ...PhoneNumberFeature) []PhoneNumberInfo {
+	var filtered []PhoneNumberInfo
+	for i := range numbers {
+		ok := true
+		for _, f := range features {
+			ok = ok && numbers[i].HasFeature(f)
+		}
+		if ok {
+			filtered = append(filtered, numbers[i])
+		}
+	}
+	return filtered
+}
+
+// ListAccountPhoneNumbers returns a page of the phone numbers of the account
+func (a *API) ListAccountPhoneNumbers(ctx context.Context, params url.Values) (*PhoneNumberList, error) {
+	var l PhoneNumberList
+	if _, err := a.Get(ctx, fmt.Sprintf("/restapi/v1.0/account/%s/phone-number", a.AccountID), params, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// GetAccountPhoneNumber returns the given phone number of the account
+func (a *API) GetAccountPhoneNumber(ctx context.Context, id int64) (*PhoneNumberInfo, error) {
+	var p PhoneNumberInfo
+	if _, err := a.Get(ctx, fmt.Sprintf("/restapi/v1.0/account/%s/phone-number/%d", a.AccountID, id), nil, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ListExtensionPhoneNumbers returns a page of the phone numbers of the given extension
+func (a *API) ListExtensionPhoneNumbers(ctx context.Context, ext int64, params url.Values) (*PhoneNumberList, error) {
+	var l PhoneNumberList
+	if _, err := a.Get(ctx, a.extensionURL(ext, "/phone-number"), params, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// AllAccountPhoneNumbers fetches every page of the phone numbers of the account
+func (a *API) AllAccountPhoneNumbers(ctx context.Context, params url.Values) ([]PhoneNumberInfo, error) {
+	return allPhoneNumbers(params, func(p url.Values) (*PhoneNumberList, error) {
+		return a.ListAccountPhoneNumbers(ctx, p)
+	})
+}
+
+// AllExtensionPhoneNumbers fetches every page of the phone numbers of the given extension
+func (a *API) AllExtensionPhoneNumbers(ctx context.Context, ext int64, params url.Values) ([]PhoneNumberInfo, error) {
+	return allPhoneNumbers(params, func(p url.Values) (*PhoneNumberList, error) {
+		return a.ListExtensionPhoneNumbers(ctx, ext, p)
+	})
+}
+
+// SMSSenderNumbers returns the numbers of the given extension which can be
+// used as the from address of SMS messages
+func (a *API) SMSSenderNumbers(ctx context.Context, ext int64) ([]PhoneNumberInfo, error) {
+	numbers, err := a.AllExtensionPhoneNumbers(ctx, ext, nil)
+	if err != nil {
+		return nil, err
+	}
+	return FilterPhoneNumbers(numbers, PhoneNumberFeatureSmsSender), nil
+}
+
+func allPhoneNumbers(params url.Values, list func(url.Values) (*PhoneNumberList, error)) ([]PhoneNumberInfo, error) {
+	p := url.Values{}
+	for k, v := range params {
+		p[k] = v
+	}
+	var all []PhoneNumberInfo
+	for page := 1; ; page++ {
+		p.Set("page", strconv.Itoa(page))
+		l, err := list(p)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, l.Records...)
+		if !hasNextPage(len(l.Records), l.Paging, l.Navigation) {
+			return all, nil
+		}
+	}
+}
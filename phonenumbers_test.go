@@ -0,0 +1,59 @@
+package ringcentral
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestFilterPhoneNumbers(t *testing.T) {
+	numbers := []PhoneNumberInfo{
+		{PhoneNumber: "+16505550100", Features: []PhoneNumberFeature{PhoneNumberFeatureCallerID, PhoneNumberFeatureSmsSender}},
+		{PhoneNumber: "+16505550101", Features: []PhoneNumberFeature{PhoneNumberFeatureCallerID}},
+	}
+	sms := FilterPhoneNumbers(numbers, PhoneNumberFeatureSmsSender)
+	if assert.Len(t, sms, 1) {
+		assert.Equal(t, "+16505550100", sms[0].PhoneNumber)
+	}
+	assert.Len(t, FilterPhoneNumbers(numbers, PhoneNumberFeatureCallerID), 2)
+	assert.Empty(t, FilterPhoneNumbers(numbers, PhoneNumberFeatureCallerID, PhoneNumberFeatureMmsSender))
+}
+
+func TestAllPhoneNumbersPaging(t *testing.T) {
+	var pages []string
+	all, err := allPhoneNumbers(url.Values{"usageType": []string{"DirectNumber"}}, func(p url.Values) (*PhoneNumberList, error) {
+		pages = append(pages, p.Get("page"))
+		assert.Equal(t, "DirectNumber", p.Get("usageType"))
+		l := &PhoneNumberList{Records: []PhoneNumberInfo{{ID: int64(len(pages))}}, Paging: Paging{Page: len(pages), TotalPages: 2}}
+		return l, nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+	assert.Equal(t, []string{"1", "2"}, pages)
+}
+
+func TestGetAccountPhoneNumber(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/restapi/v1.0/account/~/phone-number/42", r.URL.Path)
+		writeJSON(w, `{
+			"id": 42,
+			"phoneNumber": "+16505550100",
+			"usageType": "DirectNumber",
+			"extension": {"id": "1001", "uri": "https://platform.ringcentral.com/restapi/v1.0/account/400/extension/1001", "extensionNumber": "101"},
+			"features": ["CallerId", "SmsSender"]
+		}`)
+	})
+
+	p, err := a.GetAccountPhoneNumber(context.Background(), 42)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "+16505550100", p.PhoneNumber)
+	assert.True(t, p.HasFeature(PhoneNumberFeatureSmsSender))
+	if assert.NotNil(t, p.Extension) {
+		assert.Equal(t, ExtensionRef{ID: "1001", URI: "https://platform.ringcentral.com/restapi/v1.0/account/400/extension/1001", ExtensionNumber: "101"}, *p.Extension)
+	}
+}
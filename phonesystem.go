@@ -0,0 +1,809 @@
+package ringcentral
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/context"
+	"gopkg.in/yaml.v3"
+)
+
+// PhoneSystemConfig declares the desired state of the extensions, call
+// queues, answering rules and IVR menus of an account. Resources are matched
+// against the live account by extension number, and answering rules by
+// extension number and rule name (or id for the predefined rules).
+//
+// The configuration is read as JSON or YAML. YAML documents use the JSON
+// field names; quote extension numbers in YAML, since 101 is a number there.
+type PhoneSystemConfig struct {
+	Extensions     []ExtensionConfig     `json:"extensions,omitempty"`
+	CallQueues     []CallQueueConfig     `json:"callQueues,omitempty"`
+	AnsweringRules []AnsweringRuleConfig `json:"answeringRules,omitempty"`
+	IVRMenus       []IVRMenuInfo         `json:"ivrMenus,omitempty"`
+}
+
+// ExtensionConfig is the desired state of an extension
+type ExtensionConfig struct {
+	ExtensionNumber string          `json:"extensionNumber"`
+	Type            ExtensionType   `json:"type,omitempty"`
+	Status          ExtensionStatus `json:"status,omitempty"`
+	Contact         ContactInfo     `json:"contact"`
+}
+
+// CallQueueConfig is the desired membership of a call queue. The queue itself
+// is a Department extension, declared with the other extensions.
+type CallQueueConfig struct {
+	ExtensionNumber string   `json:"extensionNumber"`
+	Members         []string `json:"members"`
+}
+
+// AnsweringRuleConfig is the desired state of an answering rule of an extension
+type AnsweringRuleConfig struct {
+	ExtensionNumber string            `json:"extensionNumber"`
+	Rule            AnsweringRuleInfo `json:"rule"`
+}
+
+// LoadPhoneSystemConfig reads and validates a JSON or YAML encoded PhoneSystemConfig
+func LoadPhoneSystemConfig(r io.Reader) (*PhoneSystemConfig, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("ringcentral: error reading config: %v", err)
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		if data, err = yamlToJSON(data); err != nil {
+			return nil, fmt.Errorf("ringcentral: error decoding config: %v", err)
+		}
+	}
+	var cfg PhoneSystemConfig
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("ringcentral: error decoding config: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// yamlToJSON converts a YAML document to JSON, so it can be decoded with the
+// JSON field names
+func yamlToJSON(data []byte) ([]byte, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	if v == nil {
+		v = map[string]interface{}{}
+	}
+	return json.Marshal(v)
+}
+
+// Validate checks that every resource has a key and that keys are unique
+func (cfg *PhoneSystemConfig) Validate() error {
+	seen := map[string]bool{}
+	check := func(kind ResourceKind, key string) error {
+		if key == "" {
+			return fmt.Errorf("ringcentral: %s without extension number", kind)
+		}
+		k := string(kind) + " " + key
+		if seen[k] {
+			return fmt.Errorf("ringcentral: duplicate %s", k)
+		}
+		seen[k] = true
+		return nil
+	}
+	for _, e := range cfg.Extensions {
+		if err := check(ResourceExtension, e.ExtensionNumber); err != nil {
+			return err
+		}
+	}
+	for _, m := range cfg.IVRMenus {
+		if err := check(ResourceExtension, m.ExtensionNumber); err != nil {
+			return err
+		}
+	}
+	for _, q := range cfg.CallQueues {
+		if err := check(ResourceCallQueueMembers, q.ExtensionNumber); err != nil {
+			return err
+		}
+	}
+	for _, r := range cfg.AnsweringRules {
+		if r.ExtensionNumber == "" {
+			return fmt.Errorf("ringcentral: %s without extension number", ResourceAnsweringRule)
+		}
+		if r.Rule.ID == "" && r.Rule.Name == "" {
+			return fmt.Errorf("ringcentral: %s of extension %s needs an id or a name", ResourceAnsweringRule, r.ExtensionNumber)
+		}
+		if err := check(ResourceAnsweringRule, answeringRuleKey(r.ExtensionNumber, &r.Rule)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PhoneSystemClient is the subset of the API used to plan and apply a
+// PhoneSystemConfig. It's implemented by *API.
+type PhoneSystemClient interface {
+	GetExtensionList(ctx context.Context, params url.Values) (*ExtensionList, error)
+	CreateExtension(ctx context.Context, req *ExtensionCreateRequest) (*ExtensionInfo, error)
+	UpdateExtension(ctx context.Context, id int64, req *ExtensionUpdateRequest) (*ExtensionInfo, error)
+	DeleteExtension(ctx context.Context, id int64) error
+	ListCallQueueMembers(ctx context.Context, id string, params url.Values) (*CallQueueMemberList, error)
+	BulkAssignCallQueueMembers(ctx context.Context, id string, req *CallQueueBulkAssignRequest) error
+	ListAnsweringRules(ctx context.Context, ext int64, params url.Values) (*AnsweringRuleList, error)
+	GetAnsweringRule(ctx context.Context, ext int64, id string) (*AnsweringRuleInfo, error)
+	CreateAnsweringRule(ctx context.Context, ext int64, rule *AnsweringRuleInfo) (*AnsweringRuleInfo, error)
+	UpdateAnsweringRule(ctx context.Context, ext int64, rule *AnsweringRuleInfo) (*AnsweringRuleInfo, error)
+	DeleteAnsweringRule(ctx context.Context, ext int64, id string) error
+	GetIVRMenu(ctx context.Context, id string) (*IVRMenuInfo, error)
+	CreateIVRMenu(ctx context.Context, menu *IVRMenuInfo) (*IVRMenuInfo, error)
+	UpdateIVRMenu(ctx context.Context, menu *IVRMenuInfo) (*IVRMenuInfo, error)
+}
+
+var _ PhoneSystemClient = (*API)(nil)
+
+// ChangeAction is the kind of a planned change
+type ChangeAction string
+
+// Change actions
+const (
+	ChangeCreate ChangeAction = "create"
+	ChangeUpdate ChangeAction = "update"
+	ChangeDelete ChangeAction = "delete"
+)
+
+// ResourceKind is the kind of resource a change applies to
+type ResourceKind string
+
+// Resource kinds
+const (
+	ResourceExtension        ResourceKind = "extension"
+	ResourceCallQueueMembers ResourceKind = "call queue members"
+	ResourceAnsweringRule    ResourceKind = "answering rule"
+	ResourceIVRMenu          ResourceKind = "ivr menu"
+)
+
+// prunableTypes are the extension types a PhoneSystemConfig can describe, and
+// so the only ones deleted when pruning.
+var prunableTypes = map[ExtensionType]bool{
+	ExtensionTypeUser:       true,
+	ExtensionTypeDepartment: true,
+	ExtensionTypeIvrMenu:    true,
+}
+
+// Change is a single create, update or delete of a plan
+type Change struct {
+	Action ChangeAction
+	Kind   ResourceKind
+	// Key identifies the resource, usually its extension number
+	Key string
+	// Fields lists the changed fields of an update, or the added (+) and removed (-) call queue members
+	Fields []string
+
+	apply func(ctx context.Context, s *applyState) error
+}
+
+func (c Change) String() string {
+	sign := map[ChangeAction]string{ChangeCreate: "+", ChangeUpdate: "~", ChangeDelete: "-"}[c.Action]
+	s := fmt.Sprintf("%s %s %s", sign, c.Kind, c.Key)
+	if len(c.Fields) > 0 {
+		s += " (" + strings.Join(c.Fields, ", ") + ")"
+	}
+	return s
+}
+
+// Plan is the list of changes needed to bring the live account to the desired configuration
+type Plan struct {
+	Changes []Change
+
+	state *applyState
+}
+
+// Empty returns true if the live account already matches the configuration
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Summary returns the number of creates, updates and deletes of the plan
+func (p *Plan) Summary() (creates, updates, deletes int) {
+	for _, c := range p.Changes {
+		switch c.Action {
+		case ChangeCreate:
+			creates++
+		case ChangeUpdate:
+			updates++
+		case ChangeDelete:
+			deletes++
+		}
+	}
+	return
+}
+
+func (p *Plan) String() string {
+	var buf bytes.Buffer
+	for _, c := range p.Changes {
+		buf.WriteString(c.String())
+		buf.WriteByte('\n')
+	}
+	creates, updates, deletes := p.Summary()
+	fmt.Fprintf(&buf, "%d to create, %d to update, %d to delete\n", creates, updates, deletes)
+	return buf.String()
+}
+
+// Apply executes the changes in order. It stops at the first error, which
+// names the failed change; the changes before it have been applied.
+func (p *Plan) Apply(ctx context.Context) error {
+	for _, c := range p.Changes {
+		if err := c.apply(ctx, p.state); err != nil {
+			return fmt.Errorf("ringcentral: %s: %v", c, err)
+		}
+	}
+	return nil
+}
+
+// applyState maps extension numbers to ids, including the ones created while applying
+type applyState struct {
+	client PhoneSystemClient
+	ids    map[string]int64
+}
+
+func (s *applyState) id(number string) (int64, error) {
+	id, ok := s.ids[number]
+	if !ok {
+		return 0, fmt.Errorf("unknown extension %s", number)
+	}
+	return id, nil
+}
+
+// PlanOptions control how a PhoneSystemConfig is planned and applied
+type PlanOptions struct {
+	// Prune deletes extensions and custom answering rules which aren't in the
+	// configuration. Only User, Department and IvrMenu extensions are pruned,
+	// and never while the configuration refers to them, by number or id.
+	// Answering rules are pruned only for extensions listed in AnsweringRules.
+	Prune bool
+	// DryRun makes ApplyPhoneSystemConfig return the plan without applying it
+	DryRun bool
+}
+
+// PlanPhoneSystemConfig compares cfg with the live account and returns the changes needed
+func PlanPhoneSystemConfig(ctx context.Context, c PhoneSystemClient, cfg *PhoneSystemConfig, opts PlanOptions) (*Plan, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	live, err := listAllExtensions(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	byNumber := make(map[string]ExtensionInfo, len(live))
+	state := &applyState{client: c, ids: make(map[string]int64, len(live))}
+	for _, e := range live {
+		byNumber[e.ExtensionNumber] = e
+		state.ids[e.ExtensionNumber] = e.ID
+	}
+	p := &planner{client: c, byNumber: byNumber, opts: opts}
+
+	for i := range cfg.Extensions {
+		if err := p.planExtension(&cfg.Extensions[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range cfg.IVRMenus {
+		if err := p.planIVRMenu(ctx, &cfg.IVRMenus[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range cfg.CallQueues {
+		if err := p.planCallQueue(ctx, cfg, &cfg.CallQueues[i]); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.planAnsweringRules(ctx, cfg); err != nil {
+		return nil, err
+	}
+	if opts.Prune {
+		p.pruneExtensions(cfg, live)
+	}
+
+	var changes []Change
+	for _, group := range [][]Change{p.creates, p.updates, p.members, p.rules, p.deletes} {
+		changes = append(changes, group...)
+	}
+	return &Plan{Changes: changes, state: state}, nil
+}
+
+// ApplyPhoneSystemConfig plans cfg and applies the plan unless opts.DryRun is set
+func ApplyPhoneSystemConfig(ctx context.Context, c PhoneSystemClient, cfg *PhoneSystemConfig, opts PlanOptions) (*Plan, error) {
+	plan, err := PlanPhoneSystemConfig(ctx, c, cfg, opts)
+	if err != nil || opts.DryRun {
+		return plan, err
+	}
+	return plan, plan.Apply(ctx)
+}
+
+type planner struct {
+	client   PhoneSystemClient
+	byNumber map[string]ExtensionInfo
+	opts     PlanOptions
+
+	creates, updates, members, rules, deletes []Change
+}
+
+func (p *planner) planExtension(want *ExtensionConfig) error {
+	have, ok := p.byNumber[want.ExtensionNumber]
+	if !ok {
+		p.creates = append(p.creates, Change{
+			Action: ChangeCreate,
+			Kind:   ResourceExtension,
+			Key:    want.ExtensionNumber,
+			apply: func(ctx context.Context, s *applyState) error {
+				e, err := s.client.CreateExtension(ctx, &ExtensionCreateRequest{
+					ExtensionNumber: want.ExtensionNumber,
+					Type:            want.Type,
+					Status:          want.Status,
+					Contact:         want.Contact,
+				})
+				if err != nil {
+					return err
+				}
+				s.ids[want.ExtensionNumber] = e.ID
+				return nil
+			},
+		})
+		return nil
+	}
+
+	if want.Type != "" && want.Type != have.Type {
+		return fmt.Errorf("ringcentral: extension %s type can't change from %s to %s", want.ExtensionNumber, have.Type, want.Type)
+	}
+	type updatable struct {
+		Status  ExtensionStatus `json:"status,omitempty"`
+		Contact ContactInfo     `json:"contact"`
+	}
+	fields, err := diffFields(updatable{want.Status, want.Contact}, updatable{have.Status, have.Contact})
+	if err != nil {
+		return err
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	req := &ExtensionUpdateRequest{}
+	for _, f := range fields {
+		if f == "status" {
+			req.Status = want.Status
+		} else {
+			req.Contact = &want.Contact
+		}
+	}
+	id := have.ID
+	p.updates = append(p.updates, Change{
+		Action: ChangeUpdate,
+		Kind:   ResourceExtension,
+		Key:    want.ExtensionNumber,
+		Fields: fields,
+		apply: func(ctx context.Context, s *applyState) error {
+			_, err := s.client.UpdateExtension(ctx, id, req)
+			return err
+		},
+	})
+	return nil
+}
+
+func (p *planner) planIVRMenu(ctx context.Context, want *IVRMenuInfo) error {
+	have, ok := p.byNumber[want.ExtensionNumber]
+	if !ok {
+		p.creates = append(p.creates, Change{
+			Action: ChangeCreate,
+			Kind:   ResourceIVRMenu,
+			Key:    want.ExtensionNumber,
+			apply: func(ctx context.Context, s *applyState) error {
+				m, err := s.client.CreateIVRMenu(ctx, want)
+				if err != nil {
+					return err
+				}
+				id, err := strconv.ParseInt(m.ID, 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid ivr menu id %q", m.ID)
+				}
+				s.ids[want.ExtensionNumber] = id
+				return nil
+			},
+		})
+		return nil
+	}
+	if have.Type != ExtensionTypeIvrMenu {
+		return fmt.Errorf("ringcentral: extension %s is a %s, not an ivr menu", want.ExtensionNumber, have.Type)
+	}
+
+	id := strconv.FormatInt(have.ID, 10)
+	live, err := p.client.GetIVRMenu(ctx, id)
+	if err != nil {
+		return err
+	}
+	desired := *want
+	desired.ID = ""
+	desired.URI = ""
+	fields, err := diffFields(&desired, live)
+	if err != nil {
+		return err
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	desired.ID = id
+	p.updates = append(p.updates, Change{
+		Action: ChangeUpdate,
+		Kind:   ResourceIVRMenu,
+		Key:    want.ExtensionNumber,
+		Fields: fields,
+		apply: func(ctx context.Context, s *applyState) error {
+			_, err := s.client.UpdateIVRMenu(ctx, &desired)
+			return err
+		},
+	})
+	return nil
+}
+
+func (p *planner) planCallQueue(ctx context.Context, cfg *PhoneSystemConfig, want *CallQueueConfig) error {
+	current := map[string]bool{}
+	if have, ok := p.byNumber[want.ExtensionNumber]; ok {
+		if have.Type != ExtensionTypeDepartment {
+			return fmt.Errorf("ringcentral: extension %s is a %s, not a call queue", want.ExtensionNumber, have.Type)
+		}
+		members, err := listAllCallQueueMembers(ctx, p.client, strconv.FormatInt(have.ID, 10))
+		if err != nil {
+			return err
+		}
+		for _, m := range members {
+			current[m.ExtensionNumber] = true
+		}
+	} else if !cfg.declares(want.ExtensionNumber, ExtensionTypeDepartment) {
+		return fmt.Errorf("ringcentral: call queue %s doesn't exist and isn't declared as a Department extension", want.ExtensionNumber)
+	}
+
+	var added, removed, fields []string
+	desired := map[string]bool{}
+	for _, n := range want.Members {
+		desired[n] = true
+		if !current[n] {
+			added = append(added, n)
+			fields = append(fields, "+"+n)
+		}
+	}
+	for n := range current {
+		if !desired[n] {
+			removed = append(removed, n)
+		}
+	}
+	sort.Strings(removed)
+	for _, n := range removed {
+		fields = append(fields, "-"+n)
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	queue := want.ExtensionNumber
+	p.members = append(p.members, Change{
+		Action: ChangeUpdate,
+		Kind:   ResourceCallQueueMembers,
+		Key:    queue,
+		Fields: fields,
+		apply: func(ctx context.Context, s *applyState) error {
+			req := &CallQueueBulkAssignRequest{}
+			for _, n := range added {
+				id, err := s.id(n)
+				if err != nil {
+					return err
+				}
+				req.AddedExtensionIDs = append(req.AddedExtensionIDs, strconv.FormatInt(id, 10))
+			}
+			for _, n := range removed {
+				id, err := s.id(n)
+				if err != nil {
+					return err
+				}
+				req.RemovedExtensionIDs = append(req.RemovedExtensionIDs, strconv.FormatInt(id, 10))
+			}
+			qid, err := s.id(queue)
+			if err != nil {
+				return err
+			}
+			return s.client.BulkAssignCallQueueMembers(ctx, strconv.FormatInt(qid, 10), req)
+		},
+	})
+	return nil
+}
+
+func (p *planner) planAnsweringRules(ctx context.Context, cfg *PhoneSystemConfig) error {
+	// Group the rules per extension, keeping the configuration order
+	var numbers []string
+	byExt := map[string][]*AnsweringRuleConfig{}
+	for i := range cfg.AnsweringRules {
+		r := &cfg.AnsweringRules[i]
+		if _, ok := byExt[r.ExtensionNumber]; !ok {
+			numbers = append(numbers, r.ExtensionNumber)
+		}
+		byExt[r.ExtensionNumber] = append(byExt[r.ExtensionNumber], r)
+	}
+
+	for _, number := range numbers {
+		var live []AnsweringRuleInfo
+		have, exists := p.byNumber[number]
+		if exists {
+			l, err := p.client.ListAnsweringRules(ctx, have.ID, nil)
+			if err != nil {
+				return err
+			}
+			live = l.Records
+		} else if !cfg.declares(number, "") {
+			return fmt.Errorf("ringcentral: answering rules for unknown extension %s", number)
+		}
+
+		matched := map[string]bool{}
+		for _, r := range byExt[number] {
+			want := r.Rule
+			var match *AnsweringRuleInfo
+			for i := range live {
+				if (want.ID != "" && live[i].ID == want.ID) || (want.ID == "" && live[i].Type == AnsweringRuleTypeCustom && live[i].Name == want.Name) {
+					match = &live[i]
+					break
+				}
+			}
+			key := answeringRuleKey(number, &want)
+			ext := number
+			if match == nil {
+				if want.ID != "" {
+					return fmt.Errorf("ringcentral: answering rule %s doesn't exist", key)
+				}
+				p.rules = append(p.rules, Change{
+					Action: ChangeCreate,
+					Kind:   ResourceAnsweringRule,
+					Key:    key,
+					apply: func(ctx context.Context, s *applyState) error {
+						id, err := s.id(ext)
+						if err != nil {
+							return err
+						}
+						_, err = s.client.CreateAnsweringRule(ctx, id, &want)
+						return err
+					},
+				})
+				continue
+			}
+
+			matched[match.ID] = true
+			full, err := p.client.GetAnsweringRule(ctx, have.ID, match.ID)
+			if err != nil {
+				return err
+			}
+			want.ID = ""
+			want.URI = ""
+			fields, err := diffFields(&want, full)
+			if err != nil {
+				return err
+			}
+			if len(fields) == 0 {
+				continue
+			}
+			want.ID = match.ID
+			extID := have.ID
+			p.rules = append(p.rules, Change{
+				Action: ChangeUpdate,
+				Kind:   ResourceAnsweringRule,
+				Key:    key,
+				Fields: fields,
+				apply: func(ctx context.Context, s *applyState) error {
+					_, err := s.client.UpdateAnsweringRule(ctx, extID, &want)
+					return err
+				},
+			})
+		}
+
+		if !p.opts.Prune {
+			continue
+		}
+		for _, r := range live {
+			if r.Type != AnsweringRuleTypeCustom || matched[r.ID] {
+				continue
+			}
+			extID, ruleID := have.ID, r.ID
+			p.deletes = append(p.deletes, Change{
+				Action: ChangeDelete,
+				Kind:   ResourceAnsweringRule,
+				Key:    answeringRuleKey(number, &r),
+				apply: func(ctx context.Context, s *applyState) error {
+					return s.client.DeleteAnsweringRule(ctx, extID, ruleID)
+				},
+			})
+		}
+	}
+	return nil
+}
+
+func (p *planner) pruneExtensions(cfg *PhoneSystemConfig, live []ExtensionInfo) {
+	ids, numbers := cfg.references()
+	for _, e := range live {
+		if !prunableTypes[e.Type] || numbers[e.ExtensionNumber] || ids[strconv.FormatInt(e.ID, 10)] {
+			continue
+		}
+		id := e.ID
+		p.deletes = append(p.deletes, Change{
+			Action: ChangeDelete,
+			Kind:   ResourceExtension,
+			Key:    e.ExtensionNumber,
+			apply: func(ctx context.Context, s *applyState) error {
+				return s.client.DeleteExtension(ctx, id)
+			},
+		})
+	}
+}
+
+// declares returns true if the configuration declares an extension with the
+// given number, and of the given type unless it's empty.
+func (cfg *PhoneSystemConfig) declares(number string, typ ExtensionType) bool {
+	for _, e := range cfg.Extensions {
+		if e.ExtensionNumber == number {
+			return typ == "" || e.Type == typ
+		}
+	}
+	for _, m := range cfg.IVRMenus {
+		if m.ExtensionNumber == number {
+			return typ == "" || typ == ExtensionTypeIvrMenu
+		}
+	}
+	return false
+}
+
+// references returns the ids and numbers of the extensions which the
+// configuration declares or refers to, as a call queue, queue member, owner of
+// answering rules or target of an IVR action or answering rule, so they must
+// not be pruned
+func (cfg *PhoneSystemConfig) references() (ids, numbers map[string]bool) {
+	ids, numbers = map[string]bool{}, map[string]bool{}
+	ref := func(id, number string) {
+		if id != "" {
+			ids[id] = true
+		}
+		if number != "" {
+			numbers[number] = true
+		}
+	}
+	for _, e := range cfg.Extensions {
+		ref("", e.ExtensionNumber)
+	}
+	for _, q := range cfg.CallQueues {
+		ref("", q.ExtensionNumber)
+		for _, m := range q.Members {
+			ref("", m)
+		}
+	}
+	for _, r := range cfg.AnsweringRules {
+		ref("", r.ExtensionNumber)
+		if t := r.Rule.Transfer; t != nil {
+			ref(t.Extension.ID, t.Extension.ExtensionNumber)
+		}
+		if q := r.Rule.Queue; q != nil {
+			for _, a := range q.FixedOrderAgents {
+				ref(a.Extension.ID, a.Extension.ExtensionNumber)
+			}
+		}
+		if v := r.Rule.Voicemail; v != nil {
+			ref(v.Recipient.ID, "")
+		}
+	}
+	for _, m := range cfg.IVRMenus {
+		ref("", m.ExtensionNumber)
+		for _, a := range m.Actions {
+			if a.Extension != nil {
+				ref(a.Extension.ID, "")
+			}
+		}
+	}
+	return ids, numbers
+}
+
+func answeringRuleKey(number string, r *AnsweringRuleInfo) string {
+	if r.ID == "" || (r.Name != "" && r.Type == AnsweringRuleTypeCustom) {
+		return number + "/" + r.Name
+	}
+	return number + "/" + r.ID
+}
+
+// listAllExtensions fetches every page of the account extension list
+func listAllExtensions(ctx context.Context, c PhoneSystemClient) ([]ExtensionInfo, error) {
+	var all []ExtensionInfo
+	for page := 1; ; page++ {
+		l, err := c.GetExtensionList(ctx, url.Values{"page": []string{strconv.Itoa(page)}, "perPage": []string{"1000"}})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, l.Records...)
+		if !hasNextPage(len(l.Records), l.Paging, l.Navigation) {
+			return all, nil
+		}
+	}
+}
+
+// listAllCallQueueMembers fetches every page of the members of a call queue
+func listAllCallQueueMembers(ctx context.Context, c PhoneSystemClient, id string) ([]CallQueueMember, error) {
+	var all []CallQueueMember
+	for page := 1; ; page++ {
+		l, err := c.ListCallQueueMembers(ctx, id, url.Values{"page": []string{strconv.Itoa(page)}, "perPage": []string{"1000"}})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, l.Records...)
+		if !hasNextPage(len(l.Records), l.Paging, l.Navigation) {
+			return all, nil
+		}
+	}
+}
+
+// diffFields returns the JSON paths of the fields set in desired which differ
+// in live. Fields only present in live, such as ids and uris filled in by the
+// server, are ignored.
+func diffFields(desired, live interface{}) ([]string, error) {
+	d, err := jsonValue(desired)
+	if err != nil {
+		return nil, err
+	}
+	l, err := jsonValue(live)
+	if err != nil {
+		return nil, err
+	}
+	var fields []string
+	subsetDiff("", d, l, &fields)
+	sort.Strings(fields)
+	return fields, nil
+}
+
+func jsonValue(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	err = json.Unmarshal(b, &out)
+	return out, err
+}
+
+func subsetDiff(path string, desired, live interface{}, fields *[]string) {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, _ := live.(map[string]interface{})
+		for k, v := range d {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			subsetDiff(p, v, l[k], fields)
+		}
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			*fields = append(*fields, path)
+			return
+		}
+		for i := range d {
+			var sub []string
+			subsetDiff(path, d[i], l[i], &sub)
+			if len(sub) > 0 {
+				*fields = append(*fields, path)
+				return
+			}
+		}
+	default:
+		if !reflect.DeepEqual(desired, live) {
+			*fields = append(*fields, path)
+		}
+	}
+}
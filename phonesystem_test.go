@@ -0,0 +1,362 @@
+package ringcentral
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+// fakePhoneSystem is an in-memory PhoneSystemClient
+type fakePhoneSystem struct {
+	nextID     int64
+	extensions map[int64]*ExtensionInfo
+	members    map[string][]string
+	rules      map[int64][]AnsweringRuleInfo
+	menus      map[string]*IVRMenuInfo
+	calls      []string
+	maxPerPage int
+}
+
+func newFakePhoneSystem() *fakePhoneSystem {
+	return &fakePhoneSystem{
+		nextID:     1000,
+		extensions: map[int64]*ExtensionInfo{},
+		members:    map[string][]string{},
+		rules:      map[int64][]AnsweringRuleInfo{},
+		menus:      map[string]*IVRMenuInfo{},
+	}
+}
+
+func (f *fakePhoneSystem) add(number string, typ ExtensionType, contact ContactInfo) int64 {
+	f.nextID++
+	f.extensions[f.nextID] = &ExtensionInfo{ID: f.nextID, ExtensionNumber: number, Type: typ, Status: ExtensionStatusEnabled, Contact: contact}
+	return f.nextID
+}
+
+func (f *fakePhoneSystem) byID(id string) *ExtensionInfo {
+	n, _ := strconv.ParseInt(id, 10, 64)
+	return f.extensions[n]
+}
+
+func (f *fakePhoneSystem) GetExtensionList(ctx context.Context, params url.Values) (*ExtensionList, error) {
+	var l ExtensionList
+	for _, e := range f.extensions {
+		l.Records = append(l.Records, *e)
+	}
+	sort.Slice(l.Records, func(i, j int) bool { return l.Records[i].ID < l.Records[j].ID })
+	l.Paging = Paging{Page: 1, TotalPages: 1}
+	return &l, nil
+}
+
+func (f *fakePhoneSystem) CreateExtension(ctx context.Context, req *ExtensionCreateRequest) (*ExtensionInfo, error) {
+	f.calls = append(f.calls, "CreateExtension "+req.ExtensionNumber)
+	id := f.add(req.ExtensionNumber, req.Type, req.Contact)
+	return f.extensions[id], nil
+}
+
+func (f *fakePhoneSystem) UpdateExtension(ctx context.Context, id int64, req *ExtensionUpdateRequest) (*ExtensionInfo, error) {
+	e := f.extensions[id]
+	f.calls = append(f.calls, "UpdateExtension "+e.ExtensionNumber)
+	if req.Contact != nil {
+		e.Contact = *req.Contact
+	}
+	if req.Status != "" {
+		e.Status = req.Status
+	}
+	return e, nil
+}
+
+func (f *fakePhoneSystem) DeleteExtension(ctx context.Context, id int64) error {
+	f.calls = append(f.calls, "DeleteExtension "+f.extensions[id].ExtensionNumber)
+	delete(f.extensions, id)
+	return nil
+}
+
+func (f *fakePhoneSystem) ListCallQueueMembers(ctx context.Context, id string, params url.Values) (*CallQueueMemberList, error) {
+	page, _ := strconv.Atoi(params.Get("page"))
+	perPage, _ := strconv.Atoi(params.Get("perPage"))
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || (f.maxPerPage > 0 && perPage > f.maxPerPage) {
+		perPage = f.maxPerPage
+	}
+	members := f.members[id]
+	l := CallQueueMemberList{Paging: Paging{Page: page, TotalPages: 1}}
+	if perPage > 0 {
+		l.Paging.TotalPages = (len(members) + perPage - 1) / perPage
+		start, end := (page-1)*perPage, page*perPage
+		if start > len(members) {
+			start = len(members)
+		}
+		if end > len(members) {
+			end = len(members)
+		}
+		members = members[start:end]
+	}
+	for _, m := range members {
+		l.Records = append(l.Records, CallQueueMember{ID: m, ExtensionNumber: f.byID(m).ExtensionNumber})
+	}
+	return &l, nil
+}
+
+func (f *fakePhoneSystem) BulkAssignCallQueueMembers(ctx context.Context, id string, req *CallQueueBulkAssignRequest) error {
+	f.calls = append(f.calls, "BulkAssign "+f.byID(id).ExtensionNumber)
+	var members []string
+	for _, m := range f.members[id] {
+		removed := false
+		for _, r := range req.RemovedExtensionIDs {
+			removed = removed || r == m
+		}
+		if !removed {
+			members = append(members, m)
+		}
+	}
+	f.members[id] = append(members, req.AddedExtensionIDs...)
+	return nil
+}
+
+func (f *fakePhoneSystem) ListAnsweringRules(ctx context.Context, ext int64, params url.Values) (*AnsweringRuleList, error) {
+	return &AnsweringRuleList{Records: f.rules[ext]}, nil
+}
+
+func (f *fakePhoneSystem) GetAnsweringRule(ctx context.Context, ext int64, id string) (*AnsweringRuleInfo, error) {
+	for _, r := range f.rules[ext] {
+		if r.ID == id {
+			return &r, nil
+		}
+	}
+	return nil, ErrInvalidAnsweringRuleID
+}
+
+func (f *fakePhoneSystem) CreateAnsweringRule(ctx context.Context, ext int64, rule *AnsweringRuleInfo) (*AnsweringRuleInfo, error) {
+	f.calls = append(f.calls, "CreateAnsweringRule "+rule.Name)
+	r := *rule
+	r.ID = "rule-" + rule.Name
+	f.rules[ext] = append(f.rules[ext], r)
+	return &r, nil
+}
+
+func (f *fakePhoneSystem) UpdateAnsweringRule(ctx context.Context, ext int64, rule *AnsweringRuleInfo) (*AnsweringRuleInfo, error) {
+	f.calls = append(f.calls, "UpdateAnsweringRule "+rule.ID)
+	for i := range f.rules[ext] {
+		if f.rules[ext][i].ID == rule.ID {
+			f.rules[ext][i] = *rule
+		}
+	}
+	return rule, nil
+}
+
+func (f *fakePhoneSystem) DeleteAnsweringRule(ctx context.Context, ext int64, id string) error {
+	f.calls = append(f.calls, "DeleteAnsweringRule "+id)
+	return nil
+}
+
+func (f *fakePhoneSystem) GetIVRMenu(ctx context.Context, id string) (*IVRMenuInfo, error) {
+	return f.menus[id], nil
+}
+
+func (f *fakePhoneSystem) CreateIVRMenu(ctx context.Context, menu *IVRMenuInfo) (*IVRMenuInfo, error) {
+	f.calls = append(f.calls, "CreateIVRMenu "+menu.ExtensionNumber)
+	id := strconv.FormatInt(f.add(menu.ExtensionNumber, ExtensionTypeIvrMenu, ContactInfo{}), 10)
+	m := *menu
+	m.ID = id
+	f.menus[id] = &m
+	return &m, nil
+}
+
+func (f *fakePhoneSystem) UpdateIVRMenu(ctx context.Context, menu *IVRMenuInfo) (*IVRMenuInfo, error) {
+	f.calls = append(f.calls, "UpdateIVRMenu "+menu.ExtensionNumber)
+	f.menus[menu.ID] = menu
+	return menu, nil
+}
+
+const testPhoneSystemConfig = `{
+	"extensions": [
+		{"extensionNumber": "101", "type": "User", "contact": {"firstName": "Jane", "email": "jane@example.com"}},
+		{"extensionNumber": "103", "type": "User", "contact": {"firstName": "Bob"}},
+		{"extensionNumber": "200", "type": "Department", "contact": {"firstName": "Support"}}
+	],
+	"callQueues": [
+		{"extensionNumber": "200", "members": ["101", "103"]}
+	],
+	"answeringRules": [
+		{"extensionNumber": "101", "rule": {"type": "Custom", "name": "Holiday", "callHandlingAction": "TakeMessagesOnly"}}
+	],
+	"ivrMenus": [
+		{"extensionNumber": "500", "name": "Main", "prompt": {"mode": "TextToSpeech", "text": "Hello"}}
+	]
+}`
+
+func TestPhoneSystemPlanApply(t *testing.T) {
+	ctx := context.Background()
+	f := newFakePhoneSystem()
+	jane := f.add("101", ExtensionTypeUser, ContactInfo{FirstName: "Jane", Email: "old@example.com"})
+	f.add("102", ExtensionTypeUser, ContactInfo{FirstName: "Gone"})
+	f.add("300", ExtensionTypeParkLocation, ContactInfo{})
+	queue := f.add("200", ExtensionTypeDepartment, ContactInfo{FirstName: "Support"})
+	f.members[strconv.FormatInt(queue, 10)] = []string{strconv.FormatInt(jane, 10)}
+	f.rules[jane] = []AnsweringRuleInfo{
+		{ID: AnsweringRuleIDBusinessHours, Type: AnsweringRuleTypeBusinessHours},
+		{ID: "old", Type: AnsweringRuleTypeCustom, Name: "Old"},
+	}
+
+	cfg, err := LoadPhoneSystemConfig(strings.NewReader(testPhoneSystemConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	plan, err := ApplyPhoneSystemConfig(ctx, f, cfg, PlanOptions{Prune: true, DryRun: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Empty(t, f.calls, "dry run must not change anything")
+	assert.Equal(t, []string{
+		"+ extension 103",
+		"+ ivr menu 500",
+		"~ extension 101 (contact.email)",
+		"~ call queue members 200 (+103)",
+		"+ answering rule 101/Holiday",
+		"- answering rule 101/Old",
+		"- extension 102",
+	}, planLines(plan))
+	creates, updates, deletes := plan.Summary()
+	assert.Equal(t, []int{3, 2, 2}, []int{creates, updates, deletes})
+
+	if !assert.NoError(t, plan.Apply(ctx)) {
+		return
+	}
+	assert.Equal(t, []string{
+		"CreateExtension 103",
+		"CreateIVRMenu 500",
+		"UpdateExtension 101",
+		"BulkAssign 200",
+		"CreateAnsweringRule Holiday",
+		"DeleteAnsweringRule old",
+		"DeleteExtension 102",
+	}, f.calls)
+	assert.Len(t, f.members[strconv.FormatInt(queue, 10)], 2)
+
+	// Applying again only finds the rule deleted by the fake to be pruned
+	f.rules[jane] = f.rules[jane][:1]
+	f.rules[jane] = append(f.rules[jane], AnsweringRuleInfo{ID: "rule-Holiday", Type: AnsweringRuleTypeCustom, Name: "Holiday", CallHandlingAction: CallHandlingActionTakeMessagesOnly})
+	plan, err = PlanPhoneSystemConfig(ctx, f, cfg, PlanOptions{Prune: true})
+	if assert.NoError(t, err) {
+		assert.True(t, plan.Empty(), plan.String())
+	}
+}
+
+const testPhoneSystemConfigYAML = `
+extensions:
+  - extensionNumber: "101"
+    type: User
+    contact:
+      firstName: Jane
+      email: jane@example.com
+callQueues:
+  - extensionNumber: "200"
+    members: ["101"]
+`
+
+func TestLoadPhoneSystemConfigYAML(t *testing.T) {
+	cfg, err := LoadPhoneSystemConfig(strings.NewReader(testPhoneSystemConfigYAML))
+	if !assert.NoError(t, err) {
+		return
+	}
+	if assert.Len(t, cfg.Extensions, 1) {
+		assert.Equal(t, "101", cfg.Extensions[0].ExtensionNumber)
+		assert.Equal(t, ExtensionTypeUser, cfg.Extensions[0].Type)
+		assert.Equal(t, "jane@example.com", cfg.Extensions[0].Contact.Email)
+	}
+	if assert.Len(t, cfg.CallQueues, 1) {
+		assert.Equal(t, []string{"101"}, cfg.CallQueues[0].Members)
+	}
+
+	_, err = LoadPhoneSystemConfig(strings.NewReader("extensions:\n  - extensionNumber: \"101\"\n    unknown: true\n"))
+	assert.Error(t, err)
+}
+
+func TestPhoneSystemPruneKeepsReferences(t *testing.T) {
+	f := newFakePhoneSystem()
+	jane := f.add("101", ExtensionTypeUser, ContactInfo{FirstName: "Jane"})
+	bob := f.add("103", ExtensionTypeUser, ContactInfo{FirstName: "Bob"})
+	f.add("102", ExtensionTypeUser, ContactInfo{FirstName: "Gone"})
+	queue := f.add("200", ExtensionTypeDepartment, ContactInfo{FirstName: "Support"})
+	f.members[strconv.FormatInt(queue, 10)] = []string{strconv.FormatInt(jane, 10)}
+	id := func(number string, typ ExtensionType) string {
+		return strconv.FormatInt(f.add(number, typ, ContactInfo{}), 10)
+	}
+	operator := id("104", ExtensionTypeUser)
+	sales := id("105", ExtensionTypeDepartment)
+	agent := id("106", ExtensionTypeUser)
+	mailbox := id("107", ExtensionTypeUser)
+	id("108", ExtensionTypeUser)
+	rule := AnsweringRuleInfo{
+		ID:        "rule-Holiday",
+		Type:      AnsweringRuleTypeCustom,
+		Name:      "Holiday",
+		Transfer:  &TransferredExtensionInfo{Extension: ExtensionRef{ID: sales}},
+		Queue:     &QueueInfo{FixedOrderAgents: []FixedOrderAgent{{Extension: ExtensionRef{ID: agent}}}},
+		Voicemail: &VoicemailInfo{Enabled: true, Recipient: RecipientInfo{ID: mailbox}},
+	}
+	f.rules[bob] = []AnsweringRuleInfo{rule}
+	rule.ID = ""
+
+	cfg := &PhoneSystemConfig{
+		CallQueues:     []CallQueueConfig{{ExtensionNumber: "200", Members: []string{"101"}}},
+		AnsweringRules: []AnsweringRuleConfig{{ExtensionNumber: "103", Rule: rule}},
+		IVRMenus: []IVRMenuInfo{{Name: "Main", ExtensionNumber: "300", Actions: []IVRMenuActionInfo{
+			{Input: "0", Action: IVRActionConnect, Extension: &IVRMenuExtensionInfo{ID: operator}},
+		}}},
+	}
+	plan, err := PlanPhoneSystemConfig(context.Background(), f, cfg, PlanOptions{Prune: true})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"+ ivr menu 300", "- extension 102", "- extension 108"}, planLines(plan))
+	}
+}
+
+func TestPhoneSystemCallQueueMemberPages(t *testing.T) {
+	f := newFakePhoneSystem()
+	f.maxPerPage = 2
+	cfg := &PhoneSystemConfig{CallQueues: []CallQueueConfig{{ExtensionNumber: "200"}}}
+	queue := strconv.FormatInt(f.add("200", ExtensionTypeDepartment, ContactInfo{}), 10)
+	for _, n := range []string{"101", "102", "103", "104", "105"} {
+		f.members[queue] = append(f.members[queue], strconv.FormatInt(f.add(n, ExtensionTypeUser, ContactInfo{}), 10))
+		if n != "104" {
+			cfg.CallQueues[0].Members = append(cfg.CallQueues[0].Members, n)
+		}
+	}
+
+	plan, err := PlanPhoneSystemConfig(context.Background(), f, cfg, PlanOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"~ call queue members 200 (-104)"}, planLines(plan))
+	}
+}
+
+func TestPhoneSystemConfigValidate(t *testing.T) {
+	_, err := LoadPhoneSystemConfig(strings.NewReader(`{"extensions": [{"extensionNumber": "101"}, {"extensionNumber": "101"}]}`))
+	assert.Error(t, err)
+	_, err = LoadPhoneSystemConfig(strings.NewReader(`{"unknown": true}`))
+	assert.Error(t, err)
+}
+
+func TestPhoneSystemTypeChange(t *testing.T) {
+	f := newFakePhoneSystem()
+	f.add("101", ExtensionTypeUser, ContactInfo{})
+	cfg := &PhoneSystemConfig{Extensions: []ExtensionConfig{{ExtensionNumber: "101", Type: ExtensionTypeDepartment}}}
+	_, err := PlanPhoneSystemConfig(context.Background(), f, cfg, PlanOptions{})
+	assert.Error(t, err)
+}
+
+func planLines(p *Plan) []string {
+	var lines []string
+	for _, c := range p.Changes {
+		lines = append(lines, c.String())
+	}
+	return lines
+}
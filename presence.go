@@ -0,0 +1,125 @@
+package ringcentral
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"golang.org/x/net/context"
+)
+
+var (
+	// ErrInvalidPresenceUpdate is returned by SetPresence for a nil update
+	ErrInvalidPresenceUpdate = errors.New("ringcentral: invalid presence update")
+)
+
+// PresenceInfo see https://developer.ringcentral.com/api-reference/Get-User-Presence-Status
+type PresenceInfo struct {
+	URI                 string          `json:"uri"`
+	Extension           ExtensionInfo   `json:"extension"`
+	ActiveCalls         []ActiveCall    `json:"activeCalls"`
+	AllowSeeMyPresence  bool            `json:"allowSeeMyPresence"`
+	DNDStatus           DNDStatus       `json:"dndStatus"`
+	Message             string          `json:"message"`
+	PickUpCallsOnHold   bool            `json:"pickUpCallsOnHold"`
+	PresenceStatus      PresenceStatus  `json:"presenceStatus"`
+	RingOnMonitoredCall bool            `json:"ringOnMonitoredCall"`
+	TelephonyStatus     TelephonyStatus `json:"telephonyStatus"`
+	UserStatus          PresenceStatus  `json:"userStatus"`
+	MeetingStatus       string          `json:"meetingStatus,omitempty"`
+}
+
+// PresenceUpdate is the request body for SetPresence. Only non-empty fields are updated.
+type PresenceUpdate struct {
+	UserStatus          PresenceStatus `json:"userStatus,omitempty"`
+	DNDStatus           DNDStatus      `json:"dndStatus,omitempty"`
+	Message             string         `json:"message,omitempty"`
+	AllowSeeMyPresence  *bool          `json:"allowSeeMyPresence,omitempty"`
+	RingOnMonitoredCall *bool          `json:"ringOnMonitoredCall,omitempty"`
+	PickUpCallsOnHold   *bool          `json:"pickUpCallsOnHold,omitempty"`
+}
+
+// PresenceList is a page of account presence records
+type PresenceList struct {
+	URI        string         `json:"uri"`
+	Records    []PresenceInfo `json:"records"`
+	Navigation Navigation     `json:"navigation"`
+	Paging     Paging         `json:"paging"`
+}
+
+// extensionID returns the path segment for the given extension. An id of 0
+// refers to the currently authorized extension.
+func extensionID(ext int64) string {
+	if ext == 0 {
+		return "~"
+	}
+	return strconv.FormatInt(ext, 10)
+}
+
+func (a *API) extensionURL(ext int64, path string) string {
+	return fmt.Sprintf("/restapi/v1.0/account/%s/extension/%s%s", a.AccountID, extensionID(ext), path)
+}
+
+func presenceParams(detailed bool) url.Values {
+	if !detailed {
+		return nil
+	}
+	return url.Values{"detailedTelephonyState": []string{"true"}}
+}
+
+// GetPresence returns the presence of the given extension. If detailed is
+// true, the active calls of the extension are included.
+func (a *API) GetPresence(ctx context.Context, ext int64, detailed bool) (*PresenceInfo, error) {
+	var p PresenceInfo
+	if _, err := a.Get(ctx, a.extensionURL(ext, "/presence"), presenceParams(detailed), &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// SetPresence updates the user status, do not disturb status and message of the given extension
+func (a *API) SetPresence(ctx context.Context, ext int64, update *PresenceUpdate) (*PresenceInfo, error) {
+	if update == nil {
+		return nil, ErrInvalidPresenceUpdate
+	}
+	var p PresenceInfo
+	if _, err := a.Put(ctx, a.extensionURL(ext, "/presence"), update, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GetAccountPresence returns a single page of presence records for all account extensions
+func (a *API) GetAccountPresence(ctx context.Context, detailed bool, page, perPage int) (*PresenceList, error) {
+	params := url.Values{}
+	if detailed {
+		params.Set("detailedTelephonyState", "true")
+	}
+	if page > 0 {
+		params.Set("page", strconv.Itoa(page))
+	}
+	if perPage > 0 {
+		params.Set("perPage", strconv.Itoa(perPage))
+	}
+	var l PresenceList
+	if _, err := a.Get(ctx, fmt.Sprintf("/restapi/v1.0/account/%s/presence", a.AccountID), params, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// GetAllAccountPresence fetches every page of account presence records
+func (a *API) GetAllAccountPresence(ctx context.Context, detailed bool) ([]PresenceInfo, error) {
+	var records []PresenceInfo
+	for page := 1; ; page++ {
+		l, err := a.GetAccountPresence(ctx, detailed, page, 0)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, l.Records...)
+		if !hasNextPage(len(l.Records), l.Paging, l.Navigation) {
+			return records, nil
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package ringcentral
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// PresenceBoardEntry is the current presence of a single extension on a PresenceBoard
+type PresenceBoardEntry struct {
+	ExtensionID     string
+	ExtensionNumber string
+	SubscriptionID  string
+	Sequence        int
+	TelephonyStatus TelephonyStatus
+	PresenceStatus  PresenceStatus
+	UserStatus      PresenceStatus
+	DNDStatus       DNDStatus
+	Message         string
+	ActiveCalls     []ActiveCall
+	Updated         time.Time
+}
+
+// PresenceBoard is a continuously updated view of the presence of every
+// extension in an account. Seed it with Seed, then feed it presence
+// notifications. Events are applied per extension in Sequence order and
+// stale events are discarded. Sequences are per subscription, so an event
+// from a new subscription restarts the sequence of its extension. It is safe
+// for concurrent use.
+type PresenceBoard struct {
+	mu      sync.RWMutex
+	entries map[string]*PresenceBoardEntry
+	changes chan PresenceBoardEntry
+	closed  bool
+}
+
+// NewPresenceBoard creates an empty board. Changes are published on a channel
+// with the given buffer size; if the buffer is full the change is dropped
+// rather than blocking the caller applying events.
+func NewPresenceBoard(buffer int) *PresenceBoard {
+	return &PresenceBoard{
+		entries: make(map[string]*PresenceBoardEntry),
+		changes: make(chan PresenceBoardEntry, buffer),
+	}
+}
+
+// Seed loads the current presence of all account extensions. Extensions which
+// already received an event since the last Reset are left untouched since the
+// event is newer.
+func (b *PresenceBoard) Seed(ctx context.Context, a *API) error {
+	records, err := a.GetAllAccountPresence(ctx, true)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	for _, r := range records {
+		if r.Extension.ID == 0 {
+			continue
+		}
+		id := strconv.FormatInt(r.Extension.ID, 10)
+		if e, ok := b.entries[id]; ok && e.Sequence > 0 {
+			continue
+		}
+		b.set(&PresenceBoardEntry{
+			ExtensionID:     id,
+			ExtensionNumber: r.Extension.ExtensionNumber,
+			TelephonyStatus: r.TelephonyStatus,
+			PresenceStatus:  r.PresenceStatus,
+			UserStatus:      r.UserStatus,
+			DNDStatus:       r.DNDStatus,
+			Message:         r.Message,
+			ActiveCalls:     r.ActiveCalls,
+			Updated:         now,
+		})
+	}
+	return nil
+}
+
+// ApplyPresence applies an account presence event. It returns false if the event was stale.
+func (b *PresenceBoard) ApplyPresence(ev *AccountPresenceEvent) bool {
+	if ev == nil {
+		return false
+	}
+	return b.apply(ev.SubscriptionID, &ev.Body, nil, ev.Timestamp)
+}
+
+// ApplyDetailedPresence applies a detailed extension presence event, including
+// its active calls. It returns false if the event was stale.
+func (b *PresenceBoard) ApplyDetailedPresence(ev *DetailedExtensionPresenceEvent) bool {
+	if ev == nil {
+		return false
+	}
+	p := PresenceEvent{
+		ExtensionID:     ev.Body.ExtensionID,
+		TelephonyStatus: ev.Body.TelephonyStatus,
+		Sequence:        ev.Body.Sequence,
+		PresenceStatus:  ev.Body.PresenceStatus,
+		UserStatus:      ev.Body.UserStatus,
+		DNDStatus:       ev.Body.DNDStatus,
+	}
+	calls := ev.Body.ActiveCalls
+	if calls == nil {
+		calls = []ActiveCall{}
+	}
+	return b.apply(ev.SubscriptionID, &p, calls, ev.Timestamp)
+}
+
+// Reset forgets the sequence of every extension while keeping the known
+// presence, so that the next event of each extension is applied whatever its
+// sequence and a following Seed refreshes every entry. Call it when the
+// subscription is recreated, then Seed again to catch up on missed changes.
+func (b *PresenceBoard) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, e := range b.entries {
+		e.SubscriptionID = ""
+		e.Sequence = 0
+	}
+}
+
+// apply updates the entry for the event's extension. A nil calls slice leaves
+// the known active calls unchanged, unless the extension is no longer on a call.
+func (b *PresenceBoard) apply(subscriptionID string, p *PresenceEvent, calls []ActiveCall, ts time.Time) bool {
+	if p.ExtensionID == "" {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e, ok := b.entries[p.ExtensionID]
+	if ok && e.SubscriptionID == subscriptionID && p.Sequence <= e.Sequence {
+		return false
+	}
+	n := PresenceBoardEntry{ExtensionID: p.ExtensionID}
+	if ok {
+		n = *e
+	}
+	n.SubscriptionID = subscriptionID
+	n.Sequence = p.Sequence
+	n.TelephonyStatus = p.TelephonyStatus
+	n.PresenceStatus = p.PresenceStatus
+	n.UserStatus = p.UserStatus
+	n.DNDStatus = p.DNDStatus
+	switch {
+	case calls != nil:
+		n.ActiveCalls = calls
+	case p.TelephonyStatus == TelephonyStatusNoCall:
+		n.ActiveCalls = []ActiveCall{}
+	}
+	n.Updated = ts
+	if n.Updated.IsZero() {
+		n.Updated = time.Now()
+	}
+	b.set(&n)
+	return true
+}
+
+// set stores the entry and publishes the change. It must be called with the lock held.
+func (b *PresenceBoard) set(e *PresenceBoardEntry) {
+	b.entries[e.ExtensionID] = e
+	if b.closed {
+		return
+	}
+	select {
+	case b.changes <- e.copy():
+	default:
+	}
+}
+
+// Get returns the current presence of the given extension
+func (b *PresenceBoard) Get(extensionID string) (PresenceBoardEntry, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	e, ok := b.entries[extensionID]
+	if !ok {
+		return PresenceBoardEntry{}, false
+	}
+	return e.copy(), true
+}
+
+// Snapshot returns the current presence of every extension, sorted by extension id
+func (b *PresenceBoard) Snapshot() []PresenceBoardEntry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	list := make([]PresenceBoardEntry, 0, len(b.entries))
+	for _, e := range b.entries {
+		list = append(list, e.copy())
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ExtensionID < list[j].ExtensionID })
+	return list
+}
+
+// Changes returns the channel on which updated entries are published
+func (b *PresenceBoard) Changes() <-chan PresenceBoardEntry {
+	return b.changes
+}
+
+// Close closes the changes channel. Events applied afterwards still update the board.
+func (b *PresenceBoard) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.closed {
+		b.closed = true
+		close(b.changes)
+	}
+}
+
+func (e *PresenceBoardEntry) copy() PresenceBoardEntry {
+	c := *e
+	if e.ActiveCalls != nil {
+		c.ActiveCalls = make([]ActiveCall, len(e.ActiveCalls))
+		copy(c.ActiveCalls, e.ActiveCalls)
+	}
+	return c
+}
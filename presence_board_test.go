@@ -0,0 +1,131 @@
+package ringcentral
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestPresenceBoard(t *testing.T) {
+	b := NewPresenceBoard(10)
+
+	ev := &AccountPresenceEvent{Body: PresenceEvent{
+		ExtensionID:     "100",
+		Sequence:        2,
+		TelephonyStatus: TelephonyStatusRinging,
+		PresenceStatus:  PresenceStatusBusy,
+	}}
+	assert.True(t, b.ApplyPresence(ev))
+
+	stale := &AccountPresenceEvent{Body: PresenceEvent{
+		ExtensionID:     "100",
+		Sequence:        1,
+		TelephonyStatus: TelephonyStatusNoCall,
+	}}
+	assert.False(t, b.ApplyPresence(stale))
+
+	detailed := &DetailedExtensionPresenceEvent{Body: DetailedPresenceEvent{
+		ExtensionID:     "100",
+		Sequence:        3,
+		TelephonyStatus: TelephonyStatusCallConnected,
+		PresenceStatus:  PresenceStatusBusy,
+		ActiveCalls:     []ActiveCall{{ID: "call-1", Direction: Inbound}},
+	}}
+	assert.True(t, b.ApplyDetailedPresence(detailed))
+
+	e, ok := b.Get("100")
+	if assert.True(t, ok) {
+		assert.Equal(t, 3, e.Sequence)
+		assert.Equal(t, TelephonyStatusCallConnected, e.TelephonyStatus)
+		assert.Len(t, e.ActiveCalls, 1)
+	}
+
+	// Non-detailed events keep the known active calls while on a call
+	assert.True(t, b.ApplyPresence(&AccountPresenceEvent{Body: PresenceEvent{ExtensionID: "100", Sequence: 4, TelephonyStatus: TelephonyStatusOnHold}}))
+	e, _ = b.Get("100")
+	assert.Len(t, e.ActiveCalls, 1)
+
+	// and clear them once the call is over
+	assert.True(t, b.ApplyPresence(&AccountPresenceEvent{Body: PresenceEvent{ExtensionID: "100", Sequence: 5, TelephonyStatus: TelephonyStatusNoCall}}))
+	e, _ = b.Get("100")
+	assert.Empty(t, e.ActiveCalls)
+
+	assert.True(t, b.ApplyPresence(&AccountPresenceEvent{Body: PresenceEvent{ExtensionID: "050", Sequence: 1}}))
+	snap := b.Snapshot()
+	if assert.Len(t, snap, 2) {
+		assert.Equal(t, "050", snap[0].ExtensionID)
+		assert.Equal(t, "100", snap[1].ExtensionID)
+	}
+
+	b.Close()
+	var seqs []int
+	for c := range b.Changes() {
+		if c.ExtensionID == "100" {
+			seqs = append(seqs, c.Sequence)
+		}
+	}
+	assert.Equal(t, []int{2, 3, 4, 5}, seqs)
+}
+
+func TestPresenceBoardSeed(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/restapi/v1.0/account/~/presence", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("detailedTelephonyState"))
+		writeJSON(w, `{"records": [
+			{"extension": {"id": 100, "extensionNumber": "101"}, "telephonyStatus": "Ringing", "activeCalls": [{"id": "call-1"}]},
+			{"extension": {"id": 200, "extensionNumber": "102"}, "telephonyStatus": "NoCall"},
+			{"telephonyStatus": "NoCall"}
+		], "paging": {"page": 1, "totalPages": 1}}`)
+	})
+
+	b := NewPresenceBoard(10)
+	assert.True(t, b.ApplyPresence(&AccountPresenceEvent{Body: PresenceEvent{ExtensionID: "200", Sequence: 1, TelephonyStatus: TelephonyStatusCallConnected}}))
+	if !assert.NoError(t, b.Seed(context.Background(), a)) {
+		return
+	}
+
+	snap := b.Snapshot()
+	if assert.Len(t, snap, 2) {
+		assert.Equal(t, "100", snap[0].ExtensionID)
+		assert.Equal(t, "101", snap[0].ExtensionNumber)
+		assert.Len(t, snap[0].ActiveCalls, 1)
+		// The event is newer than the seeded presence
+		assert.Equal(t, TelephonyStatusCallConnected, snap[1].TelephonyStatus)
+	}
+
+	// After a reset the seeded presence replaces it again
+	b.Reset()
+	if !assert.NoError(t, b.Seed(context.Background(), a)) {
+		return
+	}
+	e, _ := b.Get("200")
+	assert.Equal(t, TelephonyStatusNoCall, e.TelephonyStatus)
+	assert.Equal(t, "102", e.ExtensionNumber)
+}
+
+func TestPresenceBoardNewSubscription(t *testing.T) {
+	b := NewPresenceBoard(10)
+	event := func(sub string, seq int, status TelephonyStatus) *AccountPresenceEvent {
+		return &AccountPresenceEvent{SubscriptionID: sub, Body: PresenceEvent{ExtensionID: "100", Sequence: seq, TelephonyStatus: status}}
+	}
+
+	assert.True(t, b.ApplyPresence(event("sub-1", 40, TelephonyStatusRinging)))
+	assert.False(t, b.ApplyPresence(event("sub-1", 39, TelephonyStatusNoCall)))
+
+	// A new subscription starts its sequence over
+	assert.True(t, b.ApplyPresence(event("sub-2", 1, TelephonyStatusCallConnected)))
+	assert.False(t, b.ApplyPresence(event("sub-2", 1, TelephonyStatusNoCall)))
+	assert.True(t, b.ApplyPresence(event("sub-2", 2, TelephonyStatusOnHold)))
+	e, _ := b.Get("100")
+	assert.Equal(t, "sub-2", e.SubscriptionID)
+	assert.Equal(t, 2, e.Sequence)
+	assert.Equal(t, TelephonyStatusOnHold, e.TelephonyStatus)
+
+	// Reset accepts the next event whatever its sequence
+	b.Reset()
+	assert.True(t, b.ApplyPresence(event("sub-2", 1, TelephonyStatusNoCall)))
+	e, _ = b.Get("100")
+	assert.Equal(t, TelephonyStatusNoCall, e.TelephonyStatus)
+}
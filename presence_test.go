@@ -0,0 +1,80 @@
+package ringcentral
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestGetPresence(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/123/presence", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("detailedTelephonyState"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		writeJSON(w, `{
+			"uri": "https://platform.ringcentral.com/restapi/v1.0/account/~/extension/123/presence",
+			"extension": {"id": 123, "extensionNumber": "101"},
+			"presenceStatus": "Busy",
+			"telephonyStatus": "CallConnected",
+			"userStatus": "Available",
+			"dndStatus": "TakeAllCalls",
+			"activeCalls": [{"id": "call-1", "direction": "Inbound", "telephonyStatus": "CallConnected"}]
+		}`)
+	})
+
+	p, err := a.GetPresence(context.Background(), 123, true)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int64(123), p.Extension.ID)
+	assert.Equal(t, TelephonyStatusCallConnected, p.TelephonyStatus)
+	assert.Equal(t, DNDStatusTakeAllCalls, p.DNDStatus)
+	if assert.Len(t, p.ActiveCalls, 1) {
+		assert.Equal(t, "call-1", p.ActiveCalls[0].ID)
+	}
+}
+
+func TestSetPresence(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/restapi/v1.0/account/~/extension/~/presence", r.URL.Path)
+		var body map[string]interface{}
+		decodeBody(t, r, &body)
+		assert.Equal(t, map[string]interface{}{"userStatus": "Busy", "message": "In a meeting", "allowSeeMyPresence": false}, body)
+		writeJSON(w, `{"userStatus": "Busy", "message": "In a meeting", "allowSeeMyPresence": false}`)
+	})
+
+	no := false
+	p, err := a.SetPresence(context.Background(), 0, &PresenceUpdate{UserStatus: PresenceStatusBusy, Message: "In a meeting", AllowSeeMyPresence: &no})
+	if assert.NoError(t, err) {
+		assert.Equal(t, PresenceStatusBusy, p.UserStatus)
+		assert.Equal(t, "In a meeting", p.Message)
+	}
+
+	_, err = a.SetPresence(context.Background(), 0, nil)
+	assert.Equal(t, ErrInvalidPresenceUpdate, err)
+}
+
+func TestGetAllAccountPresence(t *testing.T) {
+	var pages []string
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/restapi/v1.0/account/~/presence", r.URL.Path)
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		switch page {
+		case "1":
+			writeJSON(w, `{"records": [{"extension": {"id": 1}}, {"extension": {"id": 2}}], "paging": {"page": 1, "totalPages": 2}, "navigation": {"nextPage": {"uri": "next"}}}`)
+		default:
+			writeJSON(w, `{"records": [{"extension": {"id": 3}}], "paging": {"page": 2, "totalPages": 2}}`)
+		}
+	})
+
+	records, err := a.GetAllAccountPresence(context.Background(), false)
+	if assert.NoError(t, err) && assert.Len(t, records, 3) {
+		assert.Equal(t, int64(3), records[2].Extension.ID)
+	}
+	assert.Equal(t, []string{"1", "2"}, pages)
+}
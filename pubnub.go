@@ -0,0 +1,292 @@
+package ringcentral
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// DefaultPubNubOrigin is the PubNub origin used by RingCentral
+const DefaultPubNubOrigin = "ringcentral.pubnubapi.com"
+
+// pubNubPollTimeout is longer than the 280 seconds PubNub holds a subscribe request
+const pubNubPollTimeout = 310 * time.Second
+
+var (
+	// ErrInvalidPubNubMessage is returned for messages which can't be decrypted
+	ErrInvalidPubNubMessage = errors.New("ringcentral: invalid pubnub message")
+)
+
+// PubNub receives the notifications of a TransportTypePubNum subscription by
+// long polling PubNub, decrypts them and passes them to Handler, usually a
+// Dispatcher. Feed it the DeliveryMode of the subscription with
+// SetDeliveryMode, for example from SubscriptionManager.OnSubscribe:
+//
+//	p := NewPubNub(dispatcher)
+//	m.OnSubscribe = func(s *SubscriptionInfo) { p.SetDeliveryMode(s.DeliveryMode) }
+//	go m.Run(ctx)
+//	p.Run(ctx)
+type PubNub struct {
+	// Origin is the PubNub host, or a URL such as http://127.0.0.1:8080 for
+	// testing. Defaults to DefaultPubNubOrigin over https.
+	Origin string
+	// Handler receives the notifications
+	Handler NotificationHandler
+	// HTTPClient is used for the subscribe requests. Its timeout must be
+	// longer than the PubNub long poll of about 5 minutes.
+	HTTPClient *http.Client
+	// UUID identifies this client to PubNub. NewPubNub sets a random one.
+	UUID string
+	// RetryInterval is the delay after a failed request. Defaults to 5 seconds.
+	RetryInterval time.Duration
+	// OnError, if set, is called with request, decryption and handler errors
+	OnError func(err error)
+
+	mu      sync.Mutex
+	mode    DeliveryMode
+	gen     int
+	changed chan struct{}
+	cancel  context.CancelFunc
+}
+
+// NewPubNub returns a PubNub client passing notifications to h
+func NewPubNub(h NotificationHandler) *PubNub {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return &PubNub{
+		Handler:    h,
+		HTTPClient: &http.Client{Timeout: pubNubPollTimeout},
+		UUID:       hex.EncodeToString(b),
+		changed:    make(chan struct{}, 1),
+	}
+}
+
+// SetDeliveryMode sets the channel, subscriber key and encryption key to
+// use. A running poll is interrupted and restarted on the new channel.
+func (p *PubNub) SetDeliveryMode(m DeliveryMode) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.mode = m
+	p.gen++
+	if p.cancel != nil {
+		p.cancel()
+	}
+	if p.changed == nil {
+		p.changed = make(chan struct{}, 1)
+	}
+	select {
+	case p.changed <- struct{}{}:
+	default:
+	}
+}
+
+// pubNubResponse is the response of the v2 subscribe API
+type pubNubResponse struct {
+	Timetoken struct {
+		T string `json:"t"`
+		R int    `json:"r"`
+	} `json:"t"`
+	Messages []struct {
+		Channel string          `json:"c"`
+		Data    json.RawMessage `json:"d"`
+	} `json:"m"`
+}
+
+// Run polls PubNub until ctx is done, and returns ctx.Err()
+func (p *PubNub) Run(ctx context.Context) error {
+	var (
+		gen       = -1
+		timetoken = "0"
+		region    = 0
+	)
+	for {
+		p.mu.Lock()
+		mode, current := p.mode, p.gen
+		if p.changed == nil {
+			p.changed = make(chan struct{}, 1)
+		}
+		changed := p.changed
+		p.mu.Unlock()
+		if mode.SubscriberKey == "" || mode.Address == "" {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-changed:
+				continue
+			}
+		}
+		if current != gen {
+			gen, timetoken, region = current, "0", 0
+		}
+
+		pollCtx, cancel := context.WithCancel(ctx)
+		p.mu.Lock()
+		if p.gen != gen {
+			p.mu.Unlock()
+			cancel()
+			continue
+		}
+		p.cancel = cancel
+		p.mu.Unlock()
+		resp, err := p.subscribe(pollCtx, mode, timetoken, region)
+		cancel()
+		p.mu.Lock()
+		interrupted := p.gen != gen
+		p.mu.Unlock()
+
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case interrupted:
+			// The delivery mode changed, start over on the new channel
+			continue
+		case err != nil:
+			p.fail(err)
+			if err := p.sleep(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, m := range resp.Messages {
+			data, err := decodePubNubMessage(mode, m.Data)
+			if err != nil {
+				p.fail(err)
+				continue
+			}
+			n, err := ParseNotification(data)
+			if err != nil {
+				p.fail(err)
+				continue
+			}
+			if p.Handler != nil {
+				if err := p.Handler.HandleNotification(ctx, n); err != nil {
+					p.fail(err)
+				}
+			}
+		}
+		timetoken, region = resp.Timetoken.T, resp.Timetoken.R
+	}
+}
+
+func (p *PubNub) subscribeURL(mode DeliveryMode, timetoken string, region int) string {
+	origin := p.Origin
+	if origin == "" {
+		origin = DefaultPubNubOrigin
+	}
+	if !strings.Contains(origin, "://") {
+		origin = "https://" + origin
+	}
+	params := url.Values{"tt": []string{timetoken}}
+	if region != 0 {
+		params.Set("tr", strconv.Itoa(region))
+	}
+	if p.UUID != "" {
+		params.Set("uuid", p.UUID)
+	}
+	return fmt.Sprintf("%s/v2/subscribe/%s/%s/0?%s", strings.TrimSuffix(origin, "/"), url.PathEscape(mode.SubscriberKey), url.PathEscape(mode.Address), params.Encode())
+}
+
+func (p *PubNub) subscribe(ctx context.Context, mode DeliveryMode, timetoken string, region int) (*pubNubResponse, error) {
+	req, err := http.NewRequest(http.MethodGet, p.subscribeURL(mode, timetoken, region), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	client := p.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: pubNubPollTimeout}
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ringcentral: error reading pubnub response: %v", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("ringcentral: pubnub error: %s: %s", resp.Status, string(body))
+	}
+	var r pubNubResponse
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("ringcentral: error decoding pubnub response: %v", err)
+	}
+	return &r, nil
+}
+
+func (p *PubNub) fail(err error) {
+	if p.OnError != nil {
+		p.OnError(err)
+	}
+}
+
+func (p *PubNub) sleep(ctx context.Context) error {
+	d := p.RetryInterval
+	if d <= 0 {
+		d = 5 * time.Second
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// decodePubNubMessage returns the notification payload of a message. With
+// encryption the message is a JSON string holding the base64 encoded payload,
+// encrypted with AES in ECB mode using the key of the delivery mode.
+func decodePubNubMessage(mode DeliveryMode, data json.RawMessage) ([]byte, error) {
+	if !mode.Encryption && mode.EncryptionKey == "" {
+		return data, nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, ErrInvalidPubNubMessage
+	}
+	return decryptPubNubMessage(mode.EncryptionKey, s)
+}
+
+// decryptPubNubMessage decrypts a base64 encoded AES-128-ECB message with
+// PKCS7 padding, using the base64 encoded key
+func decryptPubNubMessage(key, msg string) ([]byte, error) {
+	k, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("ringcentral: invalid encryption key: %v", err)
+	}
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		return nil, fmt.Errorf("ringcentral: invalid encryption key: %v", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(msg)
+	if err != nil || len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return nil, ErrInvalidPubNubMessage
+	}
+	out := make([]byte, len(data))
+	for i := 0; i < len(data); i += aes.BlockSize {
+		block.Decrypt(out[i:i+aes.BlockSize], data[i:i+aes.BlockSize])
+	}
+	pad := int(out[len(out)-1])
+	if pad < 1 || pad > aes.BlockSize || !bytes.Equal(out[len(out)-pad:], bytes.Repeat([]byte{byte(pad)}, pad)) {
+		return nil, ErrInvalidPubNubMessage
+	}
+	return out[:len(out)-pad], nil
+}
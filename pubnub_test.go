@@ -0,0 +1,103 @@
+package ringcentral
+
+import (
+	"bytes"
+	"crypto/aes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+const testPubNubKey = "MDEyMzQ1Njc4OWFiY2RlZg==" // 0123456789abcdef
+
+func encryptPubNubMessage(t *testing.T, key string, msg []byte) string {
+	k, _ := base64.StdEncoding.DecodeString(key)
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pad := aes.BlockSize - len(msg)%aes.BlockSize
+	msg = append(msg, bytes.Repeat([]byte{byte(pad)}, pad)...)
+	out := make([]byte, len(msg))
+	for i := 0; i < len(msg); i += aes.BlockSize {
+		block.Encrypt(out[i:i+aes.BlockSize], msg[i:i+aes.BlockSize])
+	}
+	return base64.StdEncoding.EncodeToString(out)
+}
+
+func TestDecryptPubNubMessage(t *testing.T) {
+	payload := []byte(`{"uuid": "u-1", "event": "/restapi/v1.0/account/1/extension/2/presence"}`)
+	data, err := decryptPubNubMessage(testPubNubKey, encryptPubNubMessage(t, testPubNubKey, payload))
+	assert.NoError(t, err)
+	assert.Equal(t, payload, data)
+
+	_, err = decryptPubNubMessage(testPubNubKey, "not base64!")
+	assert.Equal(t, ErrInvalidPubNubMessage, err)
+	_, err = decryptPubNubMessage("MDEyMzQ1Njc4OWFiY2RlZQ==", encryptPubNubMessage(t, testPubNubKey, payload))
+	assert.Error(t, err)
+	_, err = decryptPubNubMessage("c2hvcnQ=", encryptPubNubMessage(t, testPubNubKey, payload))
+	assert.Error(t, err)
+}
+
+func TestPubNubRun(t *testing.T) {
+	payload := `{"uuid": "u-1", "event": "/restapi/v1.0/account/1/extension/2/presence", "body": {"extensionId": "2", "sequence": 9}}`
+	encrypted, _ := json.Marshal(encryptPubNubMessage(t, testPubNubKey, []byte(payload)))
+
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.Path+"?tt="+r.URL.Query().Get("tt"))
+		mu.Unlock()
+		switch r.URL.Query().Get("tt") {
+		case "0":
+			w.Write([]byte(`{"t": {"t": "100", "r": 4}, "m": []}`))
+		case "100":
+			w.Write([]byte(`{"t": {"t": "101", "r": 4}, "m": [{"c": "chan-1", "d": ` + string(encrypted) + `}]}`))
+		default:
+			<-r.Context().Done()
+		}
+	}))
+	defer srv.Close()
+
+	received := make(chan *ExtensionPresenceEvent, 1)
+	d := NewDispatcher()
+	d.HandleExtensionPresence("", func(ctx context.Context, ev *ExtensionPresenceEvent) error {
+		received <- ev
+		return nil
+	})
+	p := NewPubNub(d)
+	p.Origin = srv.URL
+	p.OnError = func(err error) { t.Error(err) }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- p.Run(ctx) }()
+	p.SetDeliveryMode(DeliveryMode{TransportType: TransportTypePubNum, Address: "chan-1", SubscriberKey: "sub-c-1", Encryption: true, EncryptionKey: testPubNubKey})
+
+	select {
+	case ev := <-received:
+		assert.Equal(t, 9, ev.Body.Sequence)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no notification received")
+	}
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if assert.True(t, len(requests) >= 2) {
+		assert.Equal(t, "/v2/subscribe/sub-c-1/chan-1/0?tt=0", requests[0])
+		assert.True(t, strings.HasSuffix(requests[1], "tt=100"))
+	}
+}
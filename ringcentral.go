@@ -181,6 +181,9 @@ type API struct {
 	Timeout                     time.Duration
 	Token                       *Token
 	AccountID, AppID, AppSecret string
+	// BaseURL, if set, is used instead of Endpoint and EndpointTest, for
+	// example to send requests to a local test server
+	BaseURL string
 
 	lastRequest  *http.Request
 	lastResponse *http.Response
@@ -200,6 +203,12 @@ type Paging struct {
 	TotalElements int `json:"totalElements"`
 }
 
+// hasNextPage returns true if a list response with the given number of
+// records, paging and navigation is followed by another page
+func hasNextPage(records int, p Paging, n Navigation) bool {
+	return records > 0 && (n.NextPage.URI != "" || p.Page < p.TotalPages)
+}
+
 type Records struct {
 	Action CallAction `json:"action"`
 }
@@ -224,7 +233,7 @@ type ExtensionInfo struct {
 	ID               int64                         `json:"id"`
 	URI              string                        `json:"uri"`
 	Contact          ContactInfo                   `json:"contact"`
-	Departments      DepartmentInfo                `json:"department"`
+	Departments      []DepartmentInfo              `json:"departments"`
 	ExtensionNumber  string                        `json:"extensionNumber"`
 	Account          AccountInfo                   `json:"account"`
 	Name             string                        `json:"name"`
@@ -239,6 +248,8 @@ type ExtensionInfo struct {
 	StatusInfo       StatusInfo                    `json:"statusInfo"`
 	Type             ExtensionType                 `json:"type"`
 	CallQueueInfo    CallQueueInfo                 `json:"callQueueInfo"`
+	Hidden           bool                          `json:"hidden"`
+	Site             SiteInfo                      `json:"site"`
 }
 
 type StatusInfo struct {
@@ -343,6 +354,9 @@ func (a *API) getClient(ctx context.Context) *http.Client {
 }
 
 func (a *API) getEndpoint() string {
+	if a.BaseURL != "" {
+		return strings.TrimSuffix(a.BaseURL, "/")
+	}
 	if a.TestMode {
 		return EndpointTest
 	}
@@ -400,10 +414,6 @@ func (a *API) PostForm(ctx context.Context, urlStr string, form url.Values, dstV
 }
 
 func (a *API) doRequest(ctx context.Context, req *http.Request, dstVal interface{}) (*http.Response, error) {
-	if ua := req.Header.Get("User-Agent"); ua == "" {
-		req.Header.Set("User-Agent", userAgent)
-	}
-
 	// Check authentication. If basic auth is set, then use it.
 	// Otherwise, check for a valid token, and if it exists set the Auth header
 	_, _, ba := req.BasicAuth()
@@ -417,6 +427,15 @@ func (a *API) doRequest(ctx context.Context, req *http.Request, dstVal interface
 	default:
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", a.Token.AccessToken))
 	}
+	return a.send(ctx, req, dstVal)
+}
+
+// send sends req as it is, without adding any authentication, and marshals
+// the response into dstVal
+func (a *API) send(ctx context.Context, req *http.Request, dstVal interface{}) (*http.Response, error) {
+	if ua := req.Header.Get("User-Agent"); ua == "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
 
 	client := a.getClient(ctx)
 	resp, err := client.Do(req)
@@ -435,18 +454,20 @@ func (a *API) doRequest(ctx context.Context, req *http.Request, dstVal interface
 		if err != nil {
 			return resp, fmt.Errorf("ringcentral: error reading response: %v", err)
 		}
-		if len(bodyBytes) < 1 {
-			return resp, fmt.Errorf("ringcentral: api error: %s", resp.Status)
-		}
 		// Reset the body so it can be read again (debugging, etc.)
 		resp.Body = ioutil.NopCloser(bytes.NewBuffer(bodyBytes))
 
-		// If can convert to an API error, then do so and return the message/description
+		// If can convert to an API error, then do so and return the message/description.
+		// Otherwise the status or body is the message, so the status code is kept either way.
 		var e ErrorResponse
-		if err := json.Unmarshal(bodyBytes, &e); err == nil {
-			return resp, e
+		if err := json.Unmarshal(bodyBytes, &e); err != nil {
+			e = ErrorResponse{Message: string(bodyBytes)}
 		}
-		return resp, fmt.Errorf("ringcentral: error: %s", string(bodyBytes))
+		if len(bodyBytes) < 1 {
+			e.Message = resp.Status
+		}
+		e.StatusCode = resp.StatusCode
+		return resp, e
 	case dstVal == nil:
 		return resp, nil
 	default:
@@ -529,12 +550,21 @@ type ErrorResponse struct {
 	Description string          `json:"error_description"`
 	Message     string          `json:"message"`
 	Errors      []ErrorResponse `json:"errors"`
+
+	// StatusCode is the HTTP status of the response
+	StatusCode int `json:"-"`
 }
 
 func (e ErrorResponse) Error() string {
 	return fmt.Sprintf("[RingCentral API error] %s: %s %s", e.ErrorCode, e.Message, e.Description)
 }
 
+// IsNotFound returns true if err is an API error for a resource which doesn't exist
+func IsNotFound(err error) bool {
+	e, ok := err.(ErrorResponse)
+	return ok && e.StatusCode == http.StatusNotFound
+}
+
 // GetExtensionList returns a list of all account extensions
 func (a *API) GetExtensionList(ctx context.Context, params url.Values) (*ExtensionList, error) {
 	var e ExtensionList
@@ -1,5 +1,40 @@
 package ringcentral
 
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testAPI returns an authorized API which sends its requests to h
+func testAPI(t *testing.T, h http.HandlerFunc) *API {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	a := New("id", "secret", "")
+	a.BaseURL = srv.URL
+	a.Token = &Token{AccessToken: "token", Expires: time.Now().Add(time.Hour)}
+	return a
+}
+
+// decodeBody decodes the JSON body of a request received by a test server
+func decodeBody(t *testing.T, r *http.Request, dst interface{}) {
+	data, err := ioutil.ReadAll(r.Body)
+	if assert.NoError(t, err) {
+		assert.NoError(t, json.Unmarshal(data, dst), string(data))
+	}
+}
+
+// writeJSON writes a JSON response from a test server
+func writeJSON(w http.ResponseWriter, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(body))
+}
+
 // import (
 // 	"os"
 // 	"strconv"
@@ -0,0 +1,268 @@
+package ringcentral
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+const (
+	defaultRenewBefore   = 2 * time.Minute
+	defaultCheckInterval = 5 * time.Minute
+	defaultRetryInterval = 30 * time.Second
+
+	// minStepWait is the shortest wait between steps, so a subscription
+	// which expires too soon isn't renewed in a tight loop
+	minStepWait = 5 * time.Second
+)
+
+// SubscriptionClient is the part of the API used by SubscriptionManager
+type SubscriptionClient interface {
+	CreateSubscription(ctx context.Context, req *CreateSubscriptionRequest) (*SubscriptionInfo, error)
+	GetSubscription(ctx context.Context, sub string) (*SubscriptionInfo, error)
+	RenewSubscription(ctx context.Context, sub interface{}) (*SubscriptionInfo, error)
+	DeleteSubscription(ctx context.Context, sub interface{}) error
+}
+
+var _ SubscriptionClient = (*API)(nil)
+
+// SubscriptionManager keeps a subscription alive for long running services.
+// It creates the subscription, or adopts an existing one, renews it before
+// its ExpirationTime, and recreates it when it is suspended or deleted.
+//
+// Set the callbacks before calling Run. OnSubscribe is called whenever a new
+// subscription is in use, so transports can pick up its DeliveryMode.
+type SubscriptionManager struct {
+	// Request is used to create the subscription
+	Request CreateSubscriptionRequest
+	// RenewBefore is how long before expiration the subscription is renewed.
+	// Defaults to 2 minutes, and is at most half the subscription's lifetime.
+	RenewBefore time.Duration
+	// CheckInterval is how often the status of the subscription is checked
+	// between renewals. Defaults to 5 minutes; a negative value disables
+	// the checks.
+	CheckInterval time.Duration
+	// RetryInterval is the delay after a failure. Defaults to 30 seconds.
+	RetryInterval time.Duration
+
+	// OnSubscribe is called when a subscription is created, adopted or recreated
+	OnSubscribe func(sub *SubscriptionInfo)
+	// OnRenew is called when the subscription is renewed
+	OnRenew func(sub *SubscriptionInfo)
+	// OnError is called when creating, renewing or checking the subscription fails
+	OnError func(err error)
+
+	client SubscriptionClient
+
+	mu      sync.Mutex
+	sub     *SubscriptionInfo
+	adoptID string
+	err     error
+}
+
+// NewSubscriptionManager returns a manager for subscriptions created with req
+func NewSubscriptionManager(c SubscriptionClient, req CreateSubscriptionRequest) *SubscriptionManager {
+	return &SubscriptionManager{Request: req, client: c}
+}
+
+// Adopt makes the manager use the existing subscription with the given id,
+// for example one saved by a previous run, instead of creating a new one. If
+// it no longer exists or isn't active a new subscription is created.
+func (m *SubscriptionManager) Adopt(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.adoptID = id
+}
+
+// Subscription returns the current subscription, or nil if there's none yet
+func (m *SubscriptionManager) Subscription() *SubscriptionInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sub
+}
+
+// Err returns the error of the last failed operation, or nil if the last
+// operation succeeded
+func (m *SubscriptionManager) Err() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.err
+}
+
+// Healthy returns true if there's an active, unexpired subscription and the
+// last operation succeeded
+func (m *SubscriptionManager) Healthy() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.err == nil && m.sub != nil && m.sub.Status == SubscriptionStatusActive && m.sub.ExpirationTime.After(time.Now())
+}
+
+// Run maintains the subscription until ctx is done, and returns ctx.Err()
+func (m *SubscriptionManager) Run(ctx context.Context) error {
+	for {
+		wait, err := m.step(ctx)
+		m.setErr(err)
+		if err != nil {
+			if m.OnError != nil {
+				m.OnError(err)
+			}
+			wait = m.RetryInterval
+			if wait <= 0 {
+				wait = defaultRetryInterval
+			}
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Close deletes the subscription
+func (m *SubscriptionManager) Close(ctx context.Context) error {
+	m.mu.Lock()
+	sub := m.sub
+	m.sub = nil
+	m.mu.Unlock()
+	if sub == nil {
+		return nil
+	}
+	if err := m.client.DeleteSubscription(ctx, sub.ID); err != nil && !IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// step establishes, renews or checks the subscription, whichever is due, and
+// returns how long to wait before the next step
+func (m *SubscriptionManager) step(ctx context.Context) (time.Duration, error) {
+	m.mu.Lock()
+	sub, adoptID := m.sub, m.adoptID
+	m.adoptID = ""
+	m.mu.Unlock()
+
+	switch {
+	case sub == nil && adoptID != "":
+		s, err := m.client.GetSubscription(ctx, adoptID)
+		switch {
+		case err != nil && !IsNotFound(err):
+			m.Adopt(adoptID)
+			return 0, err
+		case err == nil && s.Status == SubscriptionStatusActive && s.ExpirationTime.After(time.Now()):
+			m.use(s)
+		default:
+			if err := m.recreate(ctx, s); err != nil {
+				return 0, err
+			}
+		}
+	case sub == nil:
+		if err := m.recreate(ctx, nil); err != nil {
+			return 0, err
+		}
+	case time.Until(sub.ExpirationTime) <= m.renewBefore(sub):
+		s, err := m.client.RenewSubscription(ctx, sub.ID)
+		switch {
+		case err != nil && !IsNotFound(err):
+			return 0, err
+		case err == nil && s.Status == SubscriptionStatusActive:
+			m.mu.Lock()
+			m.sub = s
+			m.mu.Unlock()
+			if m.OnRenew != nil {
+				m.OnRenew(s)
+			}
+		default:
+			if err := m.recreate(ctx, sub); err != nil {
+				return 0, err
+			}
+		}
+	default:
+		s, err := m.client.GetSubscription(ctx, sub.ID)
+		switch {
+		case err != nil && !IsNotFound(err):
+			return 0, err
+		case err != nil || s.Status != SubscriptionStatusActive:
+			if err := m.recreate(ctx, sub); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return m.nextWait(), nil
+}
+
+// recreate deletes the old subscription, if any, and creates a new one
+func (m *SubscriptionManager) recreate(ctx context.Context, old *SubscriptionInfo) error {
+	if old != nil {
+		// The old subscription is suspended or gone, so failures don't matter
+		m.client.DeleteSubscription(ctx, old.ID)
+	}
+	req := m.Request
+	req.EventFilters = append([]string(nil), m.Request.EventFilters...)
+	s, err := m.client.CreateSubscription(ctx, &req)
+	if err != nil {
+		m.mu.Lock()
+		m.sub = nil
+		m.mu.Unlock()
+		return err
+	}
+	m.use(s)
+	return nil
+}
+
+func (m *SubscriptionManager) use(s *SubscriptionInfo) {
+	m.mu.Lock()
+	m.sub = s
+	m.mu.Unlock()
+	if m.OnSubscribe != nil {
+		m.OnSubscribe(s)
+	}
+}
+
+func (m *SubscriptionManager) setErr(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+}
+
+// renewBefore returns how long before its expiration sub is renewed, capped
+// at half its lifetime so short lived subscriptions aren't renewed right away
+func (m *SubscriptionManager) renewBefore(sub *SubscriptionInfo) time.Duration {
+	d := m.RenewBefore
+	if d <= 0 {
+		d = defaultRenewBefore
+	}
+	if half := time.Duration(sub.ExpiresIn) * time.Second / 2; half > 0 && d > half {
+		d = half
+	}
+	return d
+}
+
+func (m *SubscriptionManager) checkInterval() time.Duration {
+	if m.CheckInterval == 0 {
+		return defaultCheckInterval
+	}
+	return m.CheckInterval
+}
+
+func (m *SubscriptionManager) nextWait() time.Duration {
+	sub := m.Subscription()
+	if sub == nil {
+		return 0
+	}
+	// Compare before subtracting, as time.Until saturates for a zero ExpirationTime
+	var wait time.Duration
+	if until, before := time.Until(sub.ExpirationTime), m.renewBefore(sub); until > before {
+		wait = until - before
+	}
+	if check := m.checkInterval(); check > 0 && check < wait {
+		wait = check
+	}
+	if wait < minStepWait {
+		wait = minStepWait
+	}
+	return wait
+}
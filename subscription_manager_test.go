@@ -0,0 +1,206 @@
+package ringcentral
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+type fakeSubscriptions struct {
+	subs      map[string]*SubscriptionInfo
+	nextID    int
+	expiresIn time.Duration
+	renewErr  error
+	deleted   []string
+}
+
+func newFakeSubscriptions() *fakeSubscriptions {
+	return &fakeSubscriptions{subs: map[string]*SubscriptionInfo{}, expiresIn: time.Hour}
+}
+
+func (f *fakeSubscriptions) add(status SubscriptionStatus) *SubscriptionInfo {
+	f.nextID++
+	s := &SubscriptionInfo{ID: strconv.Itoa(f.nextID), Status: status, ExpirationTime: time.Now().Add(f.expiresIn), ExpiresIn: int(f.expiresIn / time.Second)}
+	f.subs[s.ID] = s
+	return s
+}
+
+func (f *fakeSubscriptions) get(id string) (*SubscriptionInfo, error) {
+	s, ok := f.subs[id]
+	if !ok {
+		return nil, ErrorResponse{ErrorCode: "CMN-102", StatusCode: 404}
+	}
+	c := *s
+	return &c, nil
+}
+
+func (f *fakeSubscriptions) CreateSubscription(ctx context.Context, req *CreateSubscriptionRequest) (*SubscriptionInfo, error) {
+	s := f.add(SubscriptionStatusActive)
+	s.EventFilters = req.EventFilters
+	return f.get(s.ID)
+}
+
+func (f *fakeSubscriptions) GetSubscription(ctx context.Context, id string) (*SubscriptionInfo, error) {
+	return f.get(id)
+}
+
+func (f *fakeSubscriptions) RenewSubscription(ctx context.Context, sub interface{}) (*SubscriptionInfo, error) {
+	if f.renewErr != nil {
+		return nil, f.renewErr
+	}
+	id, _ := getSubscriptionID(sub)
+	if s, ok := f.subs[id]; ok {
+		s.ExpirationTime = time.Now().Add(f.expiresIn)
+	}
+	return f.get(id)
+}
+
+func (f *fakeSubscriptions) DeleteSubscription(ctx context.Context, sub interface{}) error {
+	id, _ := getSubscriptionID(sub)
+	f.deleted = append(f.deleted, id)
+	if _, ok := f.subs[id]; !ok {
+		return ErrorResponse{StatusCode: 404}
+	}
+	delete(f.subs, id)
+	return nil
+}
+
+func TestSubscriptionManagerCreateAndRenew(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeSubscriptions()
+	m := NewSubscriptionManager(f, CreateSubscriptionRequest{EventFilters: EventFilterStrings(EventFilter{}.InstantMessage())})
+	var subscribed, renewed []string
+	m.OnSubscribe = func(s *SubscriptionInfo) { subscribed = append(subscribed, s.ID) }
+	m.OnRenew = func(s *SubscriptionInfo) { renewed = append(renewed, s.ID) }
+
+	wait, err := m.step(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, subscribed)
+	assert.Equal(t, defaultCheckInterval, wait)
+	assert.Equal(t, []string{"/restapi/v1.0/account/~/extension/~/message-store/instant?type=SMS"}, m.Subscription().EventFilters)
+
+	m.sub.ExpirationTime = time.Now().Add(time.Minute)
+	_, err = m.step(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, renewed)
+
+	f.renewErr = errors.New("timeout")
+	m.sub.ExpirationTime = time.Now().Add(time.Minute)
+	_, err = m.step(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, "1", m.Subscription().ID)
+}
+
+func TestSubscriptionManagerRecreate(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeSubscriptions()
+	m := NewSubscriptionManager(f, CreateSubscriptionRequest{})
+	var subscribed []string
+	m.OnSubscribe = func(s *SubscriptionInfo) { subscribed = append(subscribed, s.ID) }
+	m.CheckInterval = time.Minute
+
+	_, err := m.step(ctx)
+	assert.NoError(t, err)
+	assert.True(t, m.Healthy())
+
+	// Suspended subscriptions are replaced when checked
+	f.subs["1"].Status = SubscriptionStatusSuspended
+	_, err = m.step(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, subscribed)
+	assert.Equal(t, []string{"1"}, f.deleted)
+
+	// Deleted subscriptions are replaced when renewed
+	delete(f.subs, "2")
+	m.sub.ExpirationTime = time.Now().Add(time.Minute)
+	_, err = m.step(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, subscribed)
+	assert.Equal(t, "3", m.Subscription().ID)
+}
+
+func TestSubscriptionManagerAdopt(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeSubscriptions()
+	existing := f.add(SubscriptionStatusActive)
+	m := NewSubscriptionManager(f, CreateSubscriptionRequest{})
+	m.Adopt(existing.ID)
+	_, err := m.step(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, existing.ID, m.Subscription().ID)
+
+	m = NewSubscriptionManager(f, CreateSubscriptionRequest{})
+	m.Adopt("404")
+	_, err = m.step(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", m.Subscription().ID)
+
+	assert.NoError(t, m.Close(ctx))
+	assert.Nil(t, m.Subscription())
+	assert.False(t, m.Healthy())
+}
+
+func TestSubscriptionManagerRun(t *testing.T) {
+	f := newFakeSubscriptions()
+	m := NewSubscriptionManager(f, CreateSubscriptionRequest{})
+	subscribed := make(chan *SubscriptionInfo, 1)
+	m.OnSubscribe = func(s *SubscriptionInfo) { subscribed <- s }
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- m.Run(ctx) }()
+	select {
+	case s := <-subscribed:
+		assert.Equal(t, "1", s.ID)
+	case <-time.After(time.Second):
+		t.Fatal("no subscription created")
+	}
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
+
+func TestSubscriptionManagerShortExpiry(t *testing.T) {
+	f := newFakeSubscriptions()
+	f.expiresIn = time.Minute
+	m := NewSubscriptionManager(f, CreateSubscriptionRequest{})
+
+	// RenewBefore is capped at half the lifetime of the subscription
+	wait, err := m.step(context.Background())
+	assert.NoError(t, err)
+	assert.InDelta(t, float64(30*time.Second), float64(wait), float64(time.Second))
+
+	// A subscription without an expiration time isn't renewed in a loop
+	m.sub.ExpirationTime = time.Time{}
+	m.sub.ExpiresIn = 0
+	assert.Equal(t, minStepWait, m.nextWait())
+
+	renewals := 0
+	m.OnRenew = func(*SubscriptionInfo) { renewals++ }
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	f.expiresIn = 0
+	assert.Equal(t, context.DeadlineExceeded, m.Run(ctx))
+	assert.Equal(t, 1, renewals)
+}
+
+func TestIsNotFound(t *testing.T) {
+	bodies := map[string]string{
+		"/restapi/v1.0/subscription/json":  `{"errorCode": "SUB-404", "message": "Subscription not found"}`,
+		"/restapi/v1.0/subscription/empty": "",
+		"/restapi/v1.0/subscription/text":  "Not Found",
+	}
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(bodies[r.URL.Path]))
+	})
+
+	for _, id := range []string{"json", "empty", "text"} {
+		_, err := a.GetSubscription(context.Background(), id)
+		assert.True(t, IsNotFound(err), "%s: %v", id, err)
+	}
+	assert.False(t, IsNotFound(errors.New("not found")))
+}
@@ -22,8 +22,9 @@ const (
 type TransportType string
 
 const (
-	TransportTypePubNum  TransportType = "PubNum"
-	TransportTypeWebHook TransportType = "WebHook"
+	TransportTypePubNum    TransportType = "PubNum"
+	TransportTypeWebHook   TransportType = "WebHook"
+	TransportTypeWebSocket TransportType = "WebSocket"
 
 	SubscriptionMaxExipresIn = 604800
 )
@@ -37,6 +38,7 @@ type DeliveryMode struct {
 	EncryptionKey       string        `json:"encryptionKey,omitempty"`
 	RegistrationID      string        `json:"registrationId,omitempty"`
 	CertificateName     string        `json:"certificateName,omitempty"`
+	VerificationToken   string        `json:"verificationToken,omitempty"`
 }
 
 type SubscriptionListResponse struct {
@@ -75,8 +77,7 @@ func (a *API) CreateSubscription(ctx context.Context, req *CreateSubscriptionReq
 		req.ExpiresIn = SubscriptionMaxExipresIn
 	}
 	var s SubscriptionInfo
-	if resp, err := a.Post(ctx, "/restapi/v1.0/subscription", req, &s); err != nil {
-		fmt.Println(resp)
+	if _, err := a.Post(ctx, "/restapi/v1.0/subscription", req, &s); err != nil {
 		return nil, err
 	}
 	return &s, nil
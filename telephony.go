@@ -0,0 +1,435 @@
+package ringcentral
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// TelephonySessionStatusCode is the status code of a telephony session party
+type TelephonySessionStatusCode string
+
+// Telephony session party status codes
+const (
+	TelephonySessionStatusSetup              TelephonySessionStatusCode = "Setup"
+	TelephonySessionStatusProceeding         TelephonySessionStatusCode = "Proceeding"
+	TelephonySessionStatusAnswered           TelephonySessionStatusCode = "Answered"
+	TelephonySessionStatusDisconnected       TelephonySessionStatusCode = "Disconnected"
+	TelephonySessionStatusGone               TelephonySessionStatusCode = "Gone"
+	TelephonySessionStatusParked             TelephonySessionStatusCode = "Parked"
+	TelephonySessionStatusHold               TelephonySessionStatusCode = "Hold"
+	TelephonySessionStatusVoicemail          TelephonySessionStatusCode = "VoiceMail"
+	TelephonySessionStatusFaxReceive         TelephonySessionStatusCode = "FaxReceive"
+	TelephonySessionStatusVoicemailScreening TelephonySessionStatusCode = "VoiceMailScreening"
+)
+
+// Finished returns true if the party is no longer part of the call
+func (c TelephonySessionStatusCode) Finished() bool {
+	return c == TelephonySessionStatusDisconnected || c == TelephonySessionStatusGone
+}
+
+// TelephonySessionStatusReason is the reason a party reached its status
+type TelephonySessionStatusReason string
+
+// Telephony session party status reasons
+const (
+	TelephonySessionReasonPickup                TelephonySessionStatusReason = "Pickup"
+	TelephonySessionReasonSupervising           TelephonySessionStatusReason = "Supervising"
+	TelephonySessionReasonTakeOver              TelephonySessionStatusReason = "TakeOver"
+	TelephonySessionReasonTimeout               TelephonySessionStatusReason = "Timeout"
+	TelephonySessionReasonBlindTransfer         TelephonySessionStatusReason = "BlindTransfer"
+	TelephonySessionReasonRccTransfer           TelephonySessionStatusReason = "RccTransfer"
+	TelephonySessionReasonAttendedTransfer      TelephonySessionStatusReason = "AttendedTransfer"
+	TelephonySessionReasonCallerInputRedirect   TelephonySessionStatusReason = "CallerInputRedirect"
+	TelephonySessionReasonCallFlip              TelephonySessionStatusReason = "CallFlip"
+	TelephonySessionReasonParkLocation          TelephonySessionStatusReason = "ParkLocation"
+	TelephonySessionReasonDtmfTransfer          TelephonySessionStatusReason = "DtmfTransfer"
+	TelephonySessionReasonAgentAnswered         TelephonySessionStatusReason = "AgentAnswered"
+	TelephonySessionReasonAgentDropped          TelephonySessionStatusReason = "AgentDropped"
+	TelephonySessionReasonRejected              TelephonySessionStatusReason = "Rejected"
+	TelephonySessionReasonCancelled             TelephonySessionStatusReason = "Cancelled"
+	TelephonySessionReasonInternalError         TelephonySessionStatusReason = "InternalError"
+	TelephonySessionReasonNoAnswer              TelephonySessionStatusReason = "NoAnswer"
+	TelephonySessionReasonTargetBusy            TelephonySessionStatusReason = "TargetBusy"
+	TelephonySessionReasonInvalidNumber         TelephonySessionStatusReason = "InvalidNumber"
+	TelephonySessionReasonInternationalDisabled TelephonySessionStatusReason = "InternationalDisabled"
+	TelephonySessionReasonDestinationBlocked    TelephonySessionStatusReason = "DestinationBlocked"
+	TelephonySessionReasonNotEnoughFunds        TelephonySessionStatusReason = "NotEnoughFunds"
+	TelephonySessionReasonNoSuchUser            TelephonySessionStatusReason = "NoSuchUser"
+	TelephonySessionReasonCallPark              TelephonySessionStatusReason = "CallPark"
+	TelephonySessionReasonCallRedirected        TelephonySessionStatusReason = "CallRedirected"
+	TelephonySessionReasonCallReplied           TelephonySessionStatusReason = "CallReplied"
+	TelephonySessionReasonCallSwitch            TelephonySessionStatusReason = "CallSwitch"
+	TelephonySessionReasonCallFinished          TelephonySessionStatusReason = "CallFinished"
+	TelephonySessionReasonCallDropped           TelephonySessionStatusReason = "CallDropped"
+)
+
+// TelephonySessionOriginType is the type of the call which started a session
+type TelephonySessionOriginType string
+
+// Telephony session origin types
+const (
+	TelephonySessionOriginCall               TelephonySessionOriginType = "Call"
+	TelephonySessionOriginRingOut            TelephonySessionOriginType = "RingOut"
+	TelephonySessionOriginRingMe             TelephonySessionOriginType = "RingMe"
+	TelephonySessionOriginConference         TelephonySessionOriginType = "Conference"
+	TelephonySessionOriginGreetingsRecording TelephonySessionOriginType = "GreetingsRecording"
+	TelephonySessionOriginVerificationCall   TelephonySessionOriginType = "VerificationCall"
+	TelephonySessionOriginZoom               TelephonySessionOriginType = "Zoom"
+	TelephonySessionOriginCallOut            TelephonySessionOriginType = "CallOut"
+)
+
+// TelephonySessionEvent see https://developer.ringcentral.com/api-reference/Account-Telephony-Sessions-Event
+type TelephonySessionEvent struct {
+	UUID           string                    `json:"uuid"`
+	Event          string                    `json:"event"`
+	SubscriptionID string                    `json:"subscriptionId"`
+	Timestamp      time.Time                 `json:"timestamp"`
+	Body           TelephonySessionEventBody `json:"body"`
+}
+
+// TelephonySessionEventBody is the body of a TelephonySessionEvent
+type TelephonySessionEventBody struct {
+	Sequence           int                     `json:"sequence"`
+	SessionID          string                  `json:"sessionId"`
+	TelephonySessionID string                  `json:"telephonySessionId"`
+	ServerID           string                  `json:"serverId"`
+	EventTime          time.Time               `json:"eventTime"`
+	Origin             TelephonySessionOrigin  `json:"origin"`
+	Parties            []TelephonySessionParty `json:"parties"`
+}
+
+// TelephonySessionOrigin describes how a telephony session was started
+type TelephonySessionOrigin struct {
+	Type TelephonySessionOriginType `json:"type"`
+}
+
+// TelephonySessionParty is a single participant of a telephony session
+type TelephonySessionParty struct {
+	ID             string                      `json:"id"`
+	AccountID      string                      `json:"accountId"`
+	ExtensionID    string                      `json:"extensionId"`
+	Direction      Direction                   `json:"direction"`
+	From           TelephonySessionPartyInfo   `json:"from"`
+	To             TelephonySessionPartyInfo   `json:"to"`
+	Status         TelephonySessionPartyStatus `json:"status"`
+	MissedCall     bool                        `json:"missedCall"`
+	StandAlone     bool                        `json:"standAlone"`
+	Muted          bool                        `json:"muted"`
+	ConferenceRole string                      `json:"conferenceRole,omitempty"`
+	RingOutRole    string                      `json:"ringOutRole,omitempty"`
+	RingMeRole     string                      `json:"ringMeRole,omitempty"`
+	Recordings     []TelephonySessionRecording `json:"recordings,omitempty"`
+	Park           *TelephonySessionParkInfo   `json:"park,omitempty"`
+}
+
+// TelephonySessionPartyInfo is the caller or callee of a party
+type TelephonySessionPartyInfo struct {
+	PhoneNumber     string `json:"phoneNumber"`
+	Name            string `json:"name"`
+	ExtensionID     string `json:"extensionId"`
+	ExtensionNumber string `json:"extensionNumber,omitempty"`
+	DeviceID        string `json:"deviceId,omitempty"`
+}
+
+// TelephonySessionPartyStatus is the current status of a party
+type TelephonySessionPartyStatus struct {
+	Code        TelephonySessionStatusCode   `json:"code"`
+	Reason      TelephonySessionStatusReason `json:"reason,omitempty"`
+	Description string                       `json:"description,omitempty"`
+	Rcc         bool                         `json:"rcc"`
+	PeerID      *TelephonySessionPeerID      `json:"peerId,omitempty"`
+}
+
+// TelephonySessionPeerID identifies the peer of a party, for example after a transfer
+type TelephonySessionPeerID struct {
+	SessionID          string `json:"sessionId"`
+	TelephonySessionID string `json:"telephonySessionId"`
+	PartyID            string `json:"partyId"`
+}
+
+// TelephonySessionRecording is a recording in progress for a party
+type TelephonySessionRecording struct {
+	ID     string `json:"id"`
+	Active bool   `json:"active"`
+}
+
+// TelephonySessionParkInfo holds the park location of a parked party
+type TelephonySessionParkInfo struct {
+	ID string `json:"id"`
+}
+
+// TelephonySession is the current state of a telephony session as seen by a TelephonySessionTracker
+type TelephonySession struct {
+	ID        string
+	SessionID string
+	Origin    TelephonySessionOrigin
+	Sequence  int
+	Updated   time.Time
+	Parties   []TelephonySessionParty
+}
+
+// Active returns true if at least one party is still on the call
+func (s *TelephonySession) Active() bool {
+	for i := range s.Parties {
+		if !s.Parties[i].Status.Code.Finished() {
+			return true
+		}
+	}
+	return false
+}
+
+// TelephonySessionTracker keeps the state of telephony sessions in memory by
+// applying TelephonySessionEvent notifications. It is safe for concurrent use.
+type TelephonySessionTracker struct {
+	// Hold is how long events following a gap in the sequence of a session
+	// are held waiting for the missing ones. Defaults to 5 seconds.
+	Hold time.Duration
+
+	mu       sync.RWMutex
+	sessions map[string]*TelephonySession
+	// held keeps the events received ahead of their turn, by session
+	held map[string]*heldTelephonyEvents
+	// finished keeps the last sequence of removed sessions so late events
+	// don't resurrect them. Entries are dropped after finishedSessionTTL.
+	finished map[string]finishedSession
+}
+
+type heldTelephonyEvents struct {
+	events []TelephonySessionEventBody
+	timer  *time.Timer
+}
+
+type finishedSession struct {
+	sequence int
+	at       time.Time
+}
+
+var (
+	finishedSessionTTL          = time.Hour
+	defaultTelephonySessionHold = 5 * time.Second
+)
+
+// NewTelephonySessionTracker returns an empty tracker
+func NewTelephonySessionTracker() *TelephonySessionTracker {
+	return &TelephonySessionTracker{
+		sessions: make(map[string]*TelephonySession),
+		held:     make(map[string]*heldTelephonyEvents),
+		finished: make(map[string]finishedSession),
+	}
+}
+
+// Apply updates the tracker with the given event. Events are applied in
+// sequence order per session, starting with sequence 1. An event received
+// after a gap is held until the missing events arrive, or for Hold, after
+// which the held events are applied in order anyway. An event with a sequence
+// already applied or held is discarded and Apply returns false.
+func (t *TelephonySessionTracker) Apply(ev *TelephonySessionEvent) bool {
+	if ev == nil || ev.Body.TelephonySessionID == "" {
+		return false
+	}
+	b := ev.Body
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.sessions == nil {
+		t.sessions = make(map[string]*TelephonySession)
+	}
+	if t.held == nil {
+		t.held = make(map[string]*heldTelephonyEvents)
+	}
+	if t.finished == nil {
+		t.finished = make(map[string]finishedSession)
+	}
+
+	last := t.lastSequence(b.TelephonySessionID)
+	switch {
+	case b.Sequence <= last:
+		return false
+	case b.Sequence > last+1:
+		return t.hold(b)
+	}
+	t.apply(b)
+	t.release(b.TelephonySessionID, false)
+	return true
+}
+
+// lastSequence returns the sequence of the last event applied to a session
+func (t *TelephonySessionTracker) lastSequence(id string) int {
+	if f, ok := t.finished[id]; ok {
+		return f.sequence
+	}
+	if s, ok := t.sessions[id]; ok {
+		return s.Sequence
+	}
+	return 0
+}
+
+// hold keeps an event until the events before it are applied
+func (t *TelephonySessionTracker) hold(b TelephonySessionEventBody) bool {
+	id := b.TelephonySessionID
+	h := t.held[id]
+	if h == nil {
+		h = &heldTelephonyEvents{}
+		t.held[id] = h
+	}
+	for _, e := range h.events {
+		if e.Sequence == b.Sequence {
+			return false
+		}
+	}
+	h.events = append(h.events, b)
+	sort.Slice(h.events, func(i, j int) bool { return h.events[i].Sequence < h.events[j].Sequence })
+	if h.timer == nil {
+		hold := t.Hold
+		if hold <= 0 {
+			hold = defaultTelephonySessionHold
+		}
+		h.timer = time.AfterFunc(hold, func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if t.held[id] == h {
+				h.timer = nil
+				t.release(id, true)
+			}
+		})
+	}
+	return true
+}
+
+// release applies the held events of a session following the last one
+// applied. If all is true the gaps are skipped and every held event is applied.
+func (t *TelephonySessionTracker) release(id string, all bool) {
+	h := t.held[id]
+	if h == nil {
+		return
+	}
+	for len(h.events) > 0 {
+		b := h.events[0]
+		last := t.lastSequence(id)
+		if b.Sequence <= last {
+			h.events = h.events[1:]
+			continue
+		}
+		if !all && b.Sequence != last+1 {
+			break
+		}
+		h.events = h.events[1:]
+		t.apply(b)
+	}
+	if len(h.events) == 0 {
+		if h.timer != nil {
+			h.timer.Stop()
+		}
+		delete(t.held, id)
+	}
+}
+
+func (t *TelephonySessionTracker) apply(b TelephonySessionEventBody) {
+	s, ok := t.sessions[b.TelephonySessionID]
+	if !ok {
+		s = &TelephonySession{ID: b.TelephonySessionID}
+		t.sessions[s.ID] = s
+	}
+
+	s.SessionID = b.SessionID
+	s.Sequence = b.Sequence
+	s.Updated = b.EventTime
+	if b.Origin.Type != "" {
+		s.Origin = b.Origin
+	}
+	for _, p := range b.Parties {
+		s.setParty(p)
+	}
+
+	if !s.Active() {
+		now := time.Now()
+		delete(t.sessions, s.ID)
+		for id, f := range t.finished {
+			if now.Sub(f.at) > finishedSessionTTL {
+				delete(t.finished, id)
+			}
+		}
+		t.finished[s.ID] = finishedSession{sequence: s.Sequence, at: now}
+	}
+}
+
+func (s *TelephonySession) setParty(p TelephonySessionParty) {
+	for i := range s.Parties {
+		if s.Parties[i].ID == p.ID {
+			s.Parties[i] = p
+			return
+		}
+	}
+	s.Parties = append(s.Parties, p)
+}
+
+// Session returns a copy of the session with the given telephony session id
+func (t *TelephonySessionTracker) Session(id string) (*TelephonySession, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	s, ok := t.sessions[id]
+	if !ok {
+		return nil, false
+	}
+	return s.copy(), true
+}
+
+// Sessions returns a copy of all the sessions currently tracked, sorted by id
+func (t *TelephonySessionTracker) Sessions() []*TelephonySession {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	list := make([]*TelephonySession, 0, len(t.sessions))
+	for _, s := range t.sessions {
+		list = append(list, s.copy())
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// ActiveCalls returns the parties belonging to the given extension which are still on a call
+func (t *TelephonySessionTracker) ActiveCalls(extensionID string) []TelephonySessionParty {
+	var parties []TelephonySessionParty
+	for _, s := range t.Sessions() {
+		for _, p := range s.Parties {
+			if p.ExtensionID == extensionID && !p.Status.Code.Finished() {
+				parties = append(parties, p)
+			}
+		}
+	}
+	return parties
+}
+
+// CallsByExtension returns the active parties of all sessions grouped by extension id
+func (t *TelephonySessionTracker) CallsByExtension() map[string][]TelephonySessionParty {
+	calls := make(map[string][]TelephonySessionParty)
+	for _, s := range t.Sessions() {
+		for _, p := range s.Parties {
+			if p.ExtensionID == "" || p.Status.Code.Finished() {
+				continue
+			}
+			calls[p.ExtensionID] = append(calls[p.ExtensionID], p)
+		}
+	}
+	return calls
+}
+
+func (s *TelephonySession) copy() *TelephonySession {
+	c := *s
+	c.Parties = make([]TelephonySessionParty, len(s.Parties))
+	for i, p := range s.Parties {
+		if p.Recordings != nil {
+			p.Recordings = append([]TelephonySessionRecording(nil), p.Recordings...)
+		}
+		if p.Park != nil {
+			park := *p.Park
+			p.Park = &park
+		}
+		if p.Status.PeerID != nil {
+			peer := *p.Status.PeerID
+			p.Status.PeerID = &peer
+		}
+		c.Parties[i] = p
+	}
+	return &c
+}
@@ -0,0 +1,130 @@
+package ringcentral
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func telephonyEvent(t *testing.T, seq int, party, ext string, code TelephonySessionStatusCode) *TelephonySessionEvent {
+	var ev TelephonySessionEvent
+	data := `{
+		"uuid": "uuid",
+		"event": "/restapi/v1.0/account/~/telephony/sessions",
+		"subscriptionId": "sub",
+		"timestamp": "2018-01-01T00:00:00Z",
+		"body": {
+			"sequence": ` + strconv.Itoa(seq) + `,
+			"sessionId": "1",
+			"telephonySessionId": "s-1",
+			"serverId": "server",
+			"eventTime": "2018-01-01T00:00:00Z",
+			"origin": {"type": "Call"},
+			"parties": [{
+				"id": "` + party + `",
+				"accountId": "100",
+				"extensionId": "` + ext + `",
+				"direction": "Inbound",
+				"from": {"phoneNumber": "+15550000001", "name": "Caller"},
+				"to": {"phoneNumber": "+15550000002", "extensionId": "` + ext + `"},
+				"status": {"code": "` + string(code) + `", "rcc": false},
+				"missedCall": false,
+				"standAlone": false,
+				"muted": false,
+				"recordings": [{"id": "rec", "active": true}]
+			}]
+		}
+	}`
+	if !assert.NoError(t, json.Unmarshal([]byte(data), &ev)) {
+		t.FailNow()
+	}
+	return &ev
+}
+
+func TestTelephonySessionEventDecode(t *testing.T) {
+	ev := telephonyEvent(t, 1, "p-1", "200", TelephonySessionStatusProceeding)
+	assert.Equal(t, "s-1", ev.Body.TelephonySessionID)
+	assert.Equal(t, TelephonySessionOriginCall, ev.Body.Origin.Type)
+	if assert.Len(t, ev.Body.Parties, 1) {
+		p := ev.Body.Parties[0]
+		assert.Equal(t, Inbound, p.Direction)
+		assert.Equal(t, TelephonySessionStatusProceeding, p.Status.Code)
+		assert.Equal(t, "+15550000001", p.From.PhoneNumber)
+		assert.Equal(t, []TelephonySessionRecording{{ID: "rec", Active: true}}, p.Recordings)
+	}
+}
+
+func TestTelephonySessionTracker(t *testing.T) {
+	tr := NewTelephonySessionTracker()
+
+	assert.True(t, tr.Apply(telephonyEvent(t, 1, "p-1", "200", TelephonySessionStatusProceeding)))
+	assert.True(t, tr.Apply(telephonyEvent(t, 2, "p-1", "200", TelephonySessionStatusAnswered)))
+	// Stale events are discarded
+	assert.False(t, tr.Apply(telephonyEvent(t, 1, "p-1", "200", TelephonySessionStatusProceeding)))
+
+	calls := tr.ActiveCalls("200")
+	if assert.Len(t, calls, 1) {
+		assert.Equal(t, TelephonySessionStatusAnswered, calls[0].Status.Code)
+	}
+	assert.Len(t, tr.CallsByExtension()["200"], 1)
+	assert.Empty(t, tr.ActiveCalls("300"))
+
+	assert.True(t, tr.Apply(telephonyEvent(t, 3, "p-1", "200", TelephonySessionStatusDisconnected)))
+	assert.Empty(t, tr.ActiveCalls("200"))
+	assert.Empty(t, tr.Sessions())
+
+	// A late event must not bring a finished session back
+	assert.False(t, tr.Apply(telephonyEvent(t, 2, "p-1", "200", TelephonySessionStatusAnswered)))
+	_, ok := tr.Session("s-1")
+	assert.False(t, ok)
+}
+
+func TestTelephonySessionTrackerReorders(t *testing.T) {
+	tr := NewTelephonySessionTracker()
+
+	assert.True(t, tr.Apply(telephonyEvent(t, 1, "p-1", "200", TelephonySessionStatusSetup)))
+	// Events after a gap are held until the missing ones arrive
+	assert.True(t, tr.Apply(telephonyEvent(t, 3, "p-1", "200", TelephonySessionStatusAnswered)))
+	assert.False(t, tr.Apply(telephonyEvent(t, 3, "p-1", "200", TelephonySessionStatusAnswered)))
+	s, ok := tr.Session("s-1")
+	if assert.True(t, ok) {
+		assert.Equal(t, 1, s.Sequence)
+		assert.Equal(t, TelephonySessionStatusSetup, s.Parties[0].Status.Code)
+	}
+
+	assert.True(t, tr.Apply(telephonyEvent(t, 2, "p-1", "200", TelephonySessionStatusProceeding)))
+	s, ok = tr.Session("s-1")
+	if assert.True(t, ok) {
+		assert.Equal(t, 3, s.Sequence)
+		assert.Equal(t, TelephonySessionStatusAnswered, s.Parties[0].Status.Code)
+	}
+}
+
+func TestTelephonySessionTrackerHold(t *testing.T) {
+	tr := NewTelephonySessionTracker()
+	tr.Hold = 10 * time.Millisecond
+
+	// Sequence 1 never arrives, so sequence 2 is applied after Hold
+	assert.True(t, tr.Apply(telephonyEvent(t, 2, "p-1", "200", TelephonySessionStatusAnswered)))
+	assert.Empty(t, tr.Sessions())
+	assert.Eventually(t, func() bool { return len(tr.ActiveCalls("200")) == 1 }, time.Second, 5*time.Millisecond)
+	assert.False(t, tr.Apply(telephonyEvent(t, 1, "p-1", "200", TelephonySessionStatusProceeding)))
+}
+
+func TestTelephonySessionCopy(t *testing.T) {
+	tr := NewTelephonySessionTracker()
+	ev := telephonyEvent(t, 1, "p-1", "200", TelephonySessionStatusParked)
+	ev.Body.Parties[0].Park = &TelephonySessionParkInfo{ID: "801"}
+	assert.True(t, tr.Apply(ev))
+
+	s, _ := tr.Session("s-1")
+	s.Parties[0].Recordings[0].Active = false
+	s.Parties[0].Park.ID = "802"
+
+	s, _ = tr.Session("s-1")
+	assert.True(t, s.Parties[0].Recordings[0].Active)
+	assert.Equal(t, "801", s.Parties[0].Park.ID)
+}
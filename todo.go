@@ -9,8 +9,8 @@ const (
 )
 
 const (
-	TimeFormat12H = "12h"
-	TimeFormat24H = "24h"
+	TimeFormat12H TimeFormat = "12h"
+	TimeFormat24H TimeFormat = "24h"
 )
 
 type VoiceNameType string
@@ -26,29 +26,34 @@ type AccountInfo struct {
 	URI string `json:"uri"`
 }
 
+type SiteInfo struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
 type ContactInfo struct {
-	FirstName        string             `json:"firstName"`
-	LastName         string             `json:"lastName"`
-	Company          string             `json:"company"`
-	Email            string             `json:"email"`
-	BusinessPhone    string             `json:"businessPhone"`
-	BusinessAddress  ContactAddressInfo `json:"businessAddress"`
-	EmailAsLoginName bool               `json:"emailAsLoginName"`
-	PronouncedName   PronouncedNameInfo `json:"pronouncedName"`
-	Department       string             `json:"department"`
+	FirstName        string              `json:"firstName,omitempty"`
+	LastName         string              `json:"lastName,omitempty"`
+	Company          string              `json:"company,omitempty"`
+	Email            string              `json:"email,omitempty"`
+	BusinessPhone    string              `json:"businessPhone,omitempty"`
+	BusinessAddress  *ContactAddressInfo `json:"businessAddress,omitempty"`
+	EmailAsLoginName *bool               `json:"emailAsLoginName,omitempty"`
+	PronouncedName   *PronouncedNameInfo `json:"pronouncedName,omitempty"`
+	Department       string              `json:"department,omitempty"`
 }
 
 type ContactAddressInfo struct {
-	Country string `json:"country"`
-	State   string `json:"state"`
-	City    string `json:"city"`
-	Street  string `json:"street"`
-	Zip     string `json:"zip"`
+	Country string `json:"country,omitempty"`
+	State   string `json:"state,omitempty"`
+	City    string `json:"city,omitempty"`
+	Street  string `json:"street,omitempty"`
+	Zip     string `json:"zip,omitempty"`
 }
 
 type PronouncedNameInfo struct {
-	Type VoiceNameType `json:"type"`
-	Text string        `json:"text"`
+	Type VoiceNameType `json:"type,omitempty"`
+	Text string        `json:"text,omitempty"`
 }
 
 type APIVersion struct {
@@ -58,9 +63,38 @@ type APIVersion struct {
 	URIString     string    `json:"uriString"`
 }
 
-type ExtendedPermissions struct{}
-type ProfileImageInfo struct{}
-type ReferenceInfo struct{}
+type ExtendedPermissions struct {
+	Admin                PermissionInfo `json:"admin"`
+	InternationalCalling PermissionInfo `json:"internationalCalling"`
+}
+
+type PermissionInfo struct {
+	Enabled bool `json:"enabled"`
+}
+
+type ProfileImageInfo struct {
+	URI          string       `json:"uri"`
+	ETag         string       `json:"etag,omitempty"`
+	LastModified *time.Time   `json:"lastModified,omitempty"`
+	ContentType  string       `json:"contentType,omitempty"`
+	Scales       []ImageScale `json:"scales,omitempty"`
+}
+
+type ImageScale struct {
+	URI string `json:"uri"`
+}
+
+type ReferenceType string
+
+const (
+	ReferenceTypePartnerID           ReferenceType = "PartnerId"
+	ReferenceTypeCustomerDirectoryID ReferenceType = "CustomerDirectoryId"
+)
+
+type ReferenceInfo struct {
+	Ref  string        `json:"ref"`
+	Type ReferenceType `json:"type"`
+}
 
 type ExtensionServiceFeatureInfo struct {
 	Enabled     bool   `json:"enabled"`
@@ -69,10 +103,10 @@ type ExtensionServiceFeatureInfo struct {
 }
 
 type CallQueueInfo struct {
-	SLAGoal                    int64 `json:"slaGoal"`
-	SLAThresholdSeconds        int64 `json:"slaThresholdSeconds"`
-	IncludeAbandondedCalls     bool  `json:"includeAbandondedCalls"`
-	AbandondedThresholdSeconds int64 `json:"abandondedThresholdSeconds"`
+	SLAGoal                   int64 `json:"slaGoal"`
+	SLAThresholdSeconds       int64 `json:"slaThresholdSeconds"`
+	IncludeAbandonedCalls     bool  `json:"includeAbandonedCalls"`
+	AbandonedThresholdSeconds int64 `json:"abandonedThresholdSeconds"`
 }
 
 type RegionalSettings struct {
@@ -81,34 +115,48 @@ type RegionalSettings struct {
 	Language         LanguageInfo         `json:"language"`
 	GreetingLanguage GreetingLanguageInfo `json:"greetingLanguage"`
 	FormattingLocale FormattingLocaleInfo `json:"formattingLocale"`
-	TimeFormat       TimeFormat           `json:"timeFormat"`
+	TimeFormat       TimeFormat           `json:"timeFormat,omitempty"`
+}
+
+type CountryInfo struct {
+	ID                string `json:"id,omitempty"`
+	URI               string `json:"uri,omitempty"`
+	Name              string `json:"name,omitempty"`
+	IsoCode           string `json:"isoCode,omitempty"`
+	CallingCode       string `json:"callingCode,omitempty"`
+	EmergencyCalling  bool   `json:"emergencyCalling,omitempty"`
+	NumberSelling     bool   `json:"numberSelling,omitempty"`
+	LoginAllowed      bool   `json:"loginAllowed,omitempty"`
+	SignupAllowed     bool   `json:"signupAllowed,omitempty"`
+	FreeSoftphoneLine bool   `json:"freeSoftphoneLine,omitempty"`
+	LocalDialing      bool   `json:"localDialing,omitempty"`
 }
 
-type CountryInfo struct{}
 type TimezoneInfo struct {
-	ID          string `json:"id"`
-	URI         string `json:"uri"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID          string `json:"id,omitempty"`
+	URI         string `json:"uri,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Bias        string `json:"bias,omitempty"`
 }
 
 type LanguageInfo struct {
-	ID               string `json:"id"`
-	URI              string `json:"uri"`
-	Greeting         bool   `json:"greeting"`
-	FormattingLocale bool   `json:"formattingLocale"`
-	LocaleCode       string `json:"localeCode"`
-	Name             string `json:"name"`
-	UI               bool   `json:"ui"`
+	ID               string `json:"id,omitempty"`
+	URI              string `json:"uri,omitempty"`
+	Greeting         bool   `json:"greeting,omitempty"`
+	FormattingLocale bool   `json:"formattingLocale,omitempty"`
+	LocaleCode       string `json:"localeCode,omitempty"`
+	Name             string `json:"name,omitempty"`
+	UI               bool   `json:"ui,omitempty"`
 }
 type GreetingLanguageInfo struct {
-	ID         string `json:"id"`
-	LocaleCode string `json:"localeCode"`
-	Name       string `json:"name"`
+	ID         string `json:"id,omitempty"`
+	LocaleCode string `json:"localeCode,omitempty"`
+	Name       string `json:"name,omitempty"`
 }
 type FormattingLocaleInfo struct {
-	ID         string `json:"id"`
-	LocaleCode string `json:"localeCode"`
-	Name       string `json:"name"`
+	ID         string `json:"id,omitempty"`
+	LocaleCode string `json:"localeCode,omitempty"`
+	Name       string `json:"name,omitempty"`
 }
 type TimeFormat string
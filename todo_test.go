@@ -1 +1,51 @@
 package ringcentral
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtensionInfoRoundTrip(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/extension.json")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var e ExtensionInfo
+	if !assert.NoError(t, json.Unmarshal(data, &e)) {
+		return
+	}
+	assert.Equal(t, int64(400131005), e.ID)
+	assert.Equal(t, ExtensionStatusDisabled, e.Status)
+	assert.Equal(t, "Voluntarily", e.StatusInfo.Reason)
+	assert.Equal(t, SetupWizardStateCompleted, e.SetupWizardState)
+	assert.Equal(t, []DepartmentInfo{{
+		ID:              "400131009",
+		URI:             "https://platform.ringcentral.com/restapi/v1.0/account/400131000/extension/400131009",
+		ExtensionNumber: "200",
+	}}, e.Departments)
+	assert.True(t, e.Permissions.Admin.Enabled)
+	assert.False(t, e.Permissions.InternationalCalling.Enabled)
+	assert.Equal(t, "image/png", e.ProfileImage.ContentType)
+	assert.Len(t, e.ProfileImage.Scales, 3)
+	assert.Equal(t, []ReferenceInfo{{Ref: "hr-42", Type: ReferenceTypePartnerID}}, e.References)
+	assert.Equal(t, "US", e.RegionalSettings.HomeCountry.IsoCode)
+	assert.Equal(t, "US/Pacific", e.RegionalSettings.Timezone.Name)
+	assert.Equal(t, TimeFormat12H, e.RegionalSettings.TimeFormat)
+	assert.Len(t, e.ServiceFeatures, 6)
+	assert.Equal(t, ExtensionServiceFeatureInfo{FeatureName: "InternationalCalling", Reason: "AccountLimitation"}, e.ServiceFeatures[5])
+	assert.Equal(t, "Support", e.Contact.Department)
+	assert.Equal(t, "Main Site", e.Site.Name)
+
+	out, err := json.Marshal(&e)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var e2 ExtensionInfo
+	if assert.NoError(t, json.Unmarshal(out, &e2)) {
+		assert.Equal(t, e, e2)
+	}
+}
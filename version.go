@@ -0,0 +1,121 @@
+package ringcentral
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/context"
+)
+
+// ModelsAPIVersion is the version of the RingCentral API the typed models of
+// this package were built against.
+const ModelsAPIVersion = "1.0.34"
+
+// ServerInfo see https://developer.ringcentral.com/api-reference/Get-API-Versions
+type ServerInfo struct {
+	URI            string       `json:"uri"`
+	APIVersions    []APIVersion `json:"apiVersions"`
+	ServerVersion  string       `json:"serverVersion"`
+	ServerRevision string       `json:"serverRevision"`
+}
+
+// ServerVersionWarning is returned by CheckServerVersion when the server runs
+// an API version older than ModelsAPIVersion. Some fields of the typed models
+// may then be missing from responses. It's a warning: the client still works.
+// It implements error so it can be logged or wrapped like one.
+type ServerVersionWarning struct {
+	ServerVersion string
+	ModelsVersion string
+}
+
+func (w *ServerVersionWarning) Error() string {
+	return fmt.Sprintf("ringcentral: server api version %s is older than %s used by this client", w.ServerVersion, w.ModelsVersion)
+}
+
+// getVersionInfo requests the version endpoints. They don't require
+// authorization, so no token or app credentials are sent.
+func (a *API) getVersionInfo(ctx context.Context, urlStr string, dstVal interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, a.makeURL(urlStr, nil), nil)
+	if err != nil {
+		return err
+	}
+	_, err = a.send(ctx, req, dstVal)
+	return err
+}
+
+// ServerInfo returns the server version and the API versions it supports
+func (a *API) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	var s ServerInfo
+	if err := a.getVersionInfo(ctx, "/restapi", &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// APIVersions returns the API versions supported by the server
+func (a *API) APIVersions(ctx context.Context) ([]APIVersion, error) {
+	s, err := a.ServerInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.APIVersions, nil
+}
+
+// APIVersion returns the details of version 1.0 of the API, which this client uses
+func (a *API) APIVersion(ctx context.Context) (*APIVersion, error) {
+	var v APIVersion
+	if err := a.getVersionInfo(ctx, "/restapi/v1.0", &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// CheckServerVersion compares the server's API version with ModelsAPIVersion.
+// If the server is older, it returns a *ServerVersionWarning as warning;
+// callers usually log it at startup and carry on. err is only set if the
+// version couldn't be requested.
+func (a *API) CheckServerVersion(ctx context.Context) (v *APIVersion, warning *ServerVersionWarning, err error) {
+	if v, err = a.APIVersion(ctx); err != nil {
+		return nil, nil, err
+	}
+	if compareVersions(v.VersionString, ModelsAPIVersion) < 0 {
+		warning = &ServerVersionWarning{ServerVersion: v.VersionString, ModelsVersion: ModelsAPIVersion}
+	}
+	return v, warning, nil
+}
+
+// compareVersions compares dotted version strings numerically. Non numeric
+// parts, such as a build suffix, are ignored.
+func compareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(v string) []int {
+	var parts []int
+	for _, s := range strings.Split(v, ".") {
+		if i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+			s = s[:i]
+		}
+		n, _ := strconv.Atoi(s)
+		parts = append(parts, n)
+	}
+	return parts
+}
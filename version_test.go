@@ -0,0 +1,77 @@
+package ringcentral
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 0, compareVersions("1.0.34", "1.0.34"))
+	assert.Equal(t, -1, compareVersions("1.0.9", "1.0.34"))
+	assert.Equal(t, 1, compareVersions("1.1", "1.0.34"))
+	assert.Equal(t, 0, compareVersions("1.0", "1.0.0"))
+	assert.Equal(t, 1, compareVersions("1.0.35-rc1", "1.0.34"))
+}
+
+func TestServerVersionWarning(t *testing.T) {
+	w := &ServerVersionWarning{ServerVersion: "1.0.30", ModelsVersion: ModelsAPIVersion}
+	assert.Contains(t, w.Error(), "1.0.30")
+}
+
+func TestServerInfo(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/restapi", r.URL.Path)
+		assert.Empty(t, r.Header.Get("Authorization"))
+		writeJSON(w, `{"uri": "https://platform.ringcentral.com/restapi", "serverVersion": "10.4.1", "apiVersions": [{"versionString": "1.0.34", "uriString": "v1.0"}]}`)
+	})
+	// The version endpoints don't need a valid token
+	a.Token.Expires = time.Now().Add(-time.Hour)
+
+	s, err := a.ServerInfo(context.Background())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "10.4.1", s.ServerVersion)
+		if assert.Len(t, s.APIVersions, 1) {
+			assert.Equal(t, "v1.0", s.APIVersions[0].URIString)
+		}
+	}
+}
+
+func TestCheckServerVersion(t *testing.T) {
+	version := "1.0.30"
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/restapi/v1.0", r.URL.Path)
+		assert.Empty(t, r.Header.Get("Authorization"))
+		writeJSON(w, `{"uriString": "v1.0", "versionString": "`+version+`"}`)
+	})
+	a.Token = nil
+
+	v, warning, err := a.CheckServerVersion(context.Background())
+	assert.NoError(t, err)
+	if assert.NotNil(t, warning) {
+		assert.Equal(t, "1.0.30", warning.ServerVersion)
+	}
+	assert.Equal(t, "1.0.30", v.VersionString)
+
+	version = ModelsAPIVersion
+	v, warning, err = a.CheckServerVersion(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, warning)
+	assert.Equal(t, ModelsAPIVersion, v.VersionString)
+}
+
+func TestCheckServerVersionError(t *testing.T) {
+	a := testAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		writeJSON(w, `{"errorCode": "CMN-211", "message": "Service temporarily unavailable"}`)
+	})
+
+	v, warning, err := a.CheckServerVersion(context.Background())
+	assert.Error(t, err)
+	assert.Nil(t, v)
+	assert.Nil(t, warning)
+}
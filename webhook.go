@@ -0,0 +1,85 @@
+package ringcentral
+
+import (
+	"crypto/subtle"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+const (
+	// ValidationTokenHeader is sent when a webhook subscription is created and
+	// must be echoed back by the receiving endpoint
+	ValidationTokenHeader = "Validation-Token"
+	// VerificationTokenHeader carries the DeliveryMode.VerificationToken of the
+	// subscription with every notification
+	VerificationTokenHeader = "Verification-Token"
+
+	maxWebhookBodySize = 1 << 20
+)
+
+// WebhookHandler is an http.Handler receiving webhook notifications. Use its
+// URL as the DeliveryMode.Address of a TransportTypeWebHook subscription.
+type WebhookHandler struct {
+	// Handler receives the decoded notifications
+	Handler NotificationHandler
+	// VerificationToken, if set, must match the Verification-Token header of
+	// every notification. Set it to the DeliveryMode.VerificationToken of the subscription.
+	VerificationToken string
+	// ErrorLog, if set, is called with the errors of Handler and with bad requests
+	ErrorLog func(r *http.Request, err error)
+}
+
+// NewWebhookHandler returns a webhook handler dispatching to h
+func NewWebhookHandler(h NotificationHandler, verificationToken string) *WebhookHandler {
+	return &WebhookHandler{Handler: h, VerificationToken: verificationToken}
+}
+
+// ServeHTTP answers the validation handshake, checks the verification token,
+// decodes the notification and passes it to the handler. If the handler
+// returns an error the response is a 500, so RingCentral delivers it again.
+func (wh *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if token := r.Header.Get(ValidationTokenHeader); token != "" {
+		w.Header().Set(ValidationTokenHeader, token)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if wh.VerificationToken != "" {
+		token := r.Header.Get(VerificationTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(wh.VerificationToken)) != 1 {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize))
+	if err != nil {
+		wh.logError(r, err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	n, err := ParseNotification(data)
+	if err != nil {
+		wh.logError(r, err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	if wh.Handler != nil {
+		if err := wh.Handler.HandleNotification(r.Context(), n); err != nil {
+			wh.logError(r, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (wh *WebhookHandler) logError(r *http.Request, err error) {
+	if wh.ErrorLog != nil {
+		wh.ErrorLog(r, err)
+	}
+}
@@ -0,0 +1,59 @@
+package ringcentral
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestWebhookHandlerValidation(t *testing.T) {
+	wh := NewWebhookHandler(nil, "secret")
+	req := httptest.NewRequest(http.MethodPost, "/webhook", nil)
+	req.Header.Set(ValidationTokenHeader, "abc123")
+	w := httptest.NewRecorder()
+	wh.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "abc123", w.Header().Get(ValidationTokenHeader))
+}
+
+func TestWebhookHandlerDispatch(t *testing.T) {
+	var got []*Notification
+	fail := false
+	wh := NewWebhookHandler(NotificationHandlerFunc(func(ctx context.Context, n *Notification) error {
+		if fail {
+			return errors.New("boom")
+		}
+		got = append(got, n)
+		return nil
+	}), "secret")
+
+	payload := `{"uuid": "u-1", "event": "/restapi/v1.0/account/1/extension/2/presence", "subscriptionId": "s-1", "body": {"extensionId": "2", "sequence": 3}}`
+	send := func(token, body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set(VerificationTokenHeader, token)
+		}
+		w := httptest.NewRecorder()
+		wh.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusForbidden, send("", payload))
+	assert.Equal(t, http.StatusForbidden, send("wrong", payload))
+	assert.Equal(t, http.StatusBadRequest, send("secret", "{"))
+	assert.Equal(t, http.StatusOK, send("secret", payload))
+	fail = true
+	assert.Equal(t, http.StatusInternalServerError, send("secret", payload))
+
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "u-1", got[0].UUID)
+		var ev ExtensionPresenceEvent
+		assert.NoError(t, got[0].Decode(&ev))
+		assert.Equal(t, 3, ev.Body.Sequence)
+	}
+}
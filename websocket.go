@@ -0,0 +1,515 @@
+package ringcentral
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	defaultHeartbeatInterval = 30 * time.Second
+	defaultReconnectDelay    = 5 * time.Second
+	defaultWriteTimeout      = 10 * time.Second
+	webSocketDialTimeout     = 30 * time.Second
+)
+
+// WebSocket message types
+const (
+	wsConnectionDetails  = "ConnectionDetails"
+	wsClientRequest      = "ClientRequest"
+	wsServerNotification = "ServerNotification"
+	wsHeartbeat          = "Heartbeat"
+	wsError              = "Error"
+
+	wsRecoverySuccessful = "Successful"
+)
+
+// WebSocketToken see https://developer.ringcentral.com/api-reference/Get-WebSocket-Token
+type WebSocketToken struct {
+	URI         string `json:"uri"`
+	AccessToken string `json:"ws_access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// WebSocketToken returns a token for connecting to the WebSocket gateway
+func (a *API) WebSocketToken(ctx context.Context) (*WebSocketToken, error) {
+	var t WebSocketToken
+	if _, err := a.PostForm(ctx, "/restapi/oauth/wstoken", url.Values{}, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// wsHeader is the first element of every WebSocket message
+type wsHeader struct {
+	Type          string     `json:"type"`
+	MessageID     string     `json:"messageId,omitempty"`
+	Status        int        `json:"status,omitempty"`
+	Method        string     `json:"method,omitempty"`
+	Path          string     `json:"path,omitempty"`
+	WSC           *wsSession `json:"wsc,omitempty"`
+	RecoveryState string     `json:"recoveryState,omitempty"`
+}
+
+// wsSession identifies the WebSocket session, so it can be recovered after a reconnect
+type wsSession struct {
+	Token    string `json:"token"`
+	Sequence int    `json:"sequence"`
+}
+
+// wsResponseFunc handles the response to a client request. An error drops
+// the connection, so Run reconnects and the request is made again.
+type wsResponseFunc func(status int, body json.RawMessage) error
+
+// wsRequest is a client request waiting for its response
+type wsRequest struct {
+	f    wsResponseFunc
+	sent time.Time
+}
+
+// WebSocket receives notifications over the RingCentral WebSocket gateway.
+// It subscribes to EventFilters over the socket, sends heartbeats, renews the
+// subscription, and reconnects when the connection drops, recovering the
+// session so no notifications are lost. Notifications go to Handler, usually
+// a Dispatcher, like with the other transports. Handler is called from its own
+// goroutine, in the order notifications arrive, so a slow handler doesn't
+// hold up heartbeats.
+type WebSocket struct {
+	// Handler receives the notifications
+	Handler NotificationHandler
+	// EventFilters are the events to subscribe to
+	EventFilters []string
+	// Token returns the gateway URI and access token. NewWebSocket uses API.WebSocketToken.
+	Token func(ctx context.Context) (*WebSocketToken, error)
+	// Origin is sent in the Origin header. Defaults to Endpoint.
+	Origin string
+	// HeartbeatInterval is how often heartbeats are sent. The connection is
+	// considered dead after two intervals without messages, or when a client
+	// request is unanswered for two intervals. Defaults to 30 seconds.
+	HeartbeatInterval time.Duration
+	// ReconnectDelay is the delay before reconnecting. Defaults to 5 seconds.
+	ReconnectDelay time.Duration
+	// RenewBefore is how long before expiration the subscription is renewed.
+	// Defaults to 2 minutes.
+	RenewBefore time.Duration
+	// WriteTimeout bounds each write, so the connection is dropped if the
+	// server stops reading. Defaults to 10 seconds.
+	WriteTimeout time.Duration
+
+	// OnSubscribe is called when a subscription is created over the socket
+	OnSubscribe func(sub *SubscriptionInfo)
+	// OnError is called with connection, subscription and handler errors
+	OnError func(err error)
+
+	// wmu serializes writes, which are done without holding mu so a stalled
+	// write doesn't hold up receiving and heartbeats
+	wmu      sync.Mutex
+	mu       sync.Mutex
+	conn     *websocket.Conn
+	session  *wsSession
+	sub      *SubscriptionInfo
+	pending  map[string]wsRequest
+	renewing bool
+	recv     time.Time
+}
+
+// NewWebSocket returns a WebSocket transport for the given event filters
+func NewWebSocket(a *API, h NotificationHandler, eventFilters ...string) *WebSocket {
+	return &WebSocket{Handler: h, EventFilters: eventFilters, Token: a.WebSocketToken}
+}
+
+// Subscription returns the current subscription, or nil if there's none
+func (w *WebSocket) Subscription() *SubscriptionInfo {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.sub
+}
+
+// Run connects to the gateway and receives notifications until ctx is done,
+// and returns ctx.Err()
+func (w *WebSocket) Run(ctx context.Context) error {
+	q := newNotificationQueue()
+	delivered := make(chan struct{})
+	go func() {
+		defer close(delivered)
+		w.deliver(ctx, q)
+	}()
+	defer func() { <-delivered }()
+
+	for {
+		err := w.connect(ctx, q)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			w.fail(err)
+		}
+		d := w.ReconnectDelay
+		if d <= 0 {
+			d = defaultReconnectDelay
+		}
+		t := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// connect runs a single connection until it fails or ctx is done. Received
+// notifications are pushed to queue.
+func (w *WebSocket) connect(ctx context.Context, queue *notificationQueue) error {
+	if w.Token == nil {
+		return errors.New("ringcentral: websocket token source not set")
+	}
+	token, err := w.Token(ctx)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	session := w.session
+	w.mu.Unlock()
+	u, err := url.Parse(token.URI)
+	if err != nil {
+		return fmt.Errorf("ringcentral: invalid websocket uri: %v", err)
+	}
+	q := u.Query()
+	q.Set("access_token", token.AccessToken)
+	if session != nil {
+		q.Set("wsc", session.Token)
+	}
+	u.RawQuery = q.Encode()
+	origin := w.Origin
+	if origin == "" {
+		origin = Endpoint
+	}
+	config, err := websocket.NewConfig(u.String(), origin)
+	if err != nil {
+		return err
+	}
+	config.Header.Set("User-Agent", userAgent)
+	config.Dialer = &net.Dialer{Timeout: webSocketDialTimeout}
+	conn, err := websocket.DialConfig(config)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+	defer conn.Close()
+
+	w.mu.Lock()
+	w.conn = conn
+	w.pending = map[string]wsRequest{}
+	w.renewing = false
+	w.recv = time.Now()
+	w.mu.Unlock()
+	defer func() {
+		// Stop the heartbeat before clearing conn, so it doesn't report
+		// sending on a connection which is being closed
+		close(done)
+		w.mu.Lock()
+		w.conn = nil
+		w.mu.Unlock()
+	}()
+
+	header, _, err := w.receive(conn)
+	if err != nil {
+		return err
+	}
+	if header.Type != wsConnectionDetails {
+		return fmt.Errorf("ringcentral: unexpected websocket message %s", header.Type)
+	}
+	w.mu.Lock()
+	recovered := session != nil && header.RecoveryState == wsRecoverySuccessful && w.sub != nil
+	if !recovered {
+		w.sub = nil
+	}
+	w.mu.Unlock()
+	if !recovered {
+		if err := w.subscribe(); err != nil {
+			return err
+		}
+	}
+
+	go w.heartbeat(ctx, conn, done)
+
+	for {
+		header, body, err := w.receive(conn)
+		if err != nil {
+			return err
+		}
+		switch header.Type {
+		case wsServerNotification:
+			n, err := ParseNotification(body)
+			if err != nil {
+				w.fail(err)
+				continue
+			}
+			queue.push(n)
+		case wsClientRequest:
+			w.mu.Lock()
+			req, ok := w.pending[header.MessageID]
+			delete(w.pending, header.MessageID)
+			w.mu.Unlock()
+			if ok {
+				if err := req.f(header.Status, body); err != nil {
+					return err
+				}
+			}
+		case wsError:
+			w.fail(fmt.Errorf("ringcentral: websocket error: %s", string(body)))
+		}
+	}
+}
+
+// receive reads a message, which is a JSON array of a header and an optional body
+func (w *WebSocket) receive(conn *websocket.Conn) (*wsHeader, json.RawMessage, error) {
+	var data []byte
+	if err := websocket.Message.Receive(conn, &data); err != nil {
+		return nil, nil, err
+	}
+	var parts []json.RawMessage
+	if err := json.Unmarshal(data, &parts); err != nil || len(parts) == 0 {
+		return nil, nil, fmt.Errorf("ringcentral: invalid websocket message: %s", string(data))
+	}
+	var h wsHeader
+	if err := json.Unmarshal(parts[0], &h); err != nil {
+		return nil, nil, fmt.Errorf("ringcentral: invalid websocket message: %s", string(data))
+	}
+	w.mu.Lock()
+	w.recv = time.Now()
+	if h.WSC != nil && h.WSC.Token != "" {
+		w.session = h.WSC
+	}
+	w.mu.Unlock()
+	var body json.RawMessage
+	if len(parts) > 1 {
+		body = parts[1]
+	}
+	return &h, body, nil
+}
+
+// send writes a message, registering f for the response of client requests
+func (w *WebSocket) send(h wsHeader, body interface{}, f wsResponseFunc) error {
+	if h.MessageID == "" {
+		h.MessageID = newMessageID()
+	}
+	msg := []interface{}{h}
+	if body != nil {
+		msg = append(msg, body)
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	conn := w.conn
+	if conn == nil {
+		w.mu.Unlock()
+		return errors.New("ringcentral: websocket not connected")
+	}
+	if f != nil {
+		w.pending[h.MessageID] = wsRequest{f: f, sent: time.Now()}
+	}
+	w.mu.Unlock()
+
+	timeout := w.WriteTimeout
+	if timeout <= 0 {
+		timeout = defaultWriteTimeout
+	}
+	w.wmu.Lock()
+	defer w.wmu.Unlock()
+	if err := conn.SetWriteDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+	return websocket.Message.Send(conn, string(data))
+}
+
+// subscribe creates a subscription to EventFilters over the socket
+func (w *WebSocket) subscribe() error {
+	req := CreateSubscriptionRequest{
+		EventFilters: w.EventFilters,
+		DeliveryMode: DeliveryMode{TransportType: TransportTypeWebSocket},
+		ExpiresIn:    SubscriptionMaxExipresIn,
+	}
+	h := wsHeader{Type: wsClientRequest, Method: "POST", Path: "/restapi/v1.0/subscription/"}
+	return w.send(h, req, w.subscribed)
+}
+
+// renew renews the subscription over the socket. If it no longer exists a
+// new one is created.
+func (w *WebSocket) renew(id string) error {
+	h := wsHeader{Type: wsClientRequest, Method: "POST", Path: "/restapi/v1.0/subscription/" + url.PathEscape(id) + "/renew"}
+	return w.send(h, nil, func(status int, body json.RawMessage) error {
+		w.mu.Lock()
+		w.renewing = false
+		if status == 404 {
+			w.sub = nil
+		}
+		w.mu.Unlock()
+		if status == 404 {
+			return w.subscribe()
+		}
+		return w.subscribed(status, body)
+	})
+}
+
+func (w *WebSocket) subscribed(status int, body json.RawMessage) error {
+	if status >= 400 {
+		return fmt.Errorf("ringcentral: websocket subscription failed with status %d: %s", status, string(body))
+	}
+	var s SubscriptionInfo
+	if err := json.Unmarshal(body, &s); err != nil {
+		return fmt.Errorf("ringcentral: error decoding subscription: %v", err)
+	}
+	w.mu.Lock()
+	prev := w.sub
+	w.sub = &s
+	w.mu.Unlock()
+	if w.OnSubscribe != nil && (prev == nil || prev.ID != s.ID) {
+		w.OnSubscribe(&s)
+	}
+	return nil
+}
+
+// heartbeat sends heartbeats and renews the subscription until done is
+// closed. It closes the connection if the server stops responding, or doesn't
+// answer a client request in time.
+func (w *WebSocket) heartbeat(ctx context.Context, conn *websocket.Conn, done chan struct{}) {
+	interval := w.HeartbeatInterval
+	if interval <= 0 {
+		interval = defaultHeartbeatInterval
+	}
+	renewBefore := w.RenewBefore
+	if renewBefore <= 0 {
+		renewBefore = defaultRenewBefore
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-t.C:
+		}
+		w.mu.Lock()
+		idle := time.Since(w.recv)
+		unanswered := false
+		for _, req := range w.pending {
+			unanswered = unanswered || time.Since(req.sent) > 2*interval
+		}
+		sub := w.sub
+		renew := sub != nil && !w.renewing && time.Until(sub.ExpirationTime) <= renewBefore
+		if renew {
+			w.renewing = true
+		}
+		w.mu.Unlock()
+		var err error
+		switch {
+		case idle > 2*interval:
+			err = errors.New("ringcentral: websocket heartbeat timeout")
+		case unanswered:
+			err = errors.New("ringcentral: websocket request timeout")
+		default:
+			err = w.send(wsHeader{Type: wsHeartbeat}, nil, nil)
+		}
+		if err == nil && renew {
+			err = w.renew(sub.ID)
+		}
+		if err != nil {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			default:
+			}
+			w.fail(err)
+			conn.Close()
+			return
+		}
+	}
+}
+
+// deliver calls Handler with the notifications pushed to q, in order, until
+// ctx is done
+func (w *WebSocket) deliver(ctx context.Context, q *notificationQueue) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.ready:
+		}
+		for _, n := range q.pop() {
+			if w.Handler == nil {
+				continue
+			}
+			if err := w.Handler.HandleNotification(ctx, n); err != nil {
+				w.fail(err)
+			}
+		}
+	}
+}
+
+// notificationQueue is an unbounded queue of notifications, so reading from
+// the socket never waits for Handler
+type notificationQueue struct {
+	mu    sync.Mutex
+	items []*Notification
+	ready chan struct{}
+}
+
+func newNotificationQueue() *notificationQueue {
+	return &notificationQueue{ready: make(chan struct{}, 1)}
+}
+
+func (q *notificationQueue) push(n *Notification) {
+	q.mu.Lock()
+	q.items = append(q.items, n)
+	q.mu.Unlock()
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// pop removes and returns all the queued notifications
+func (q *notificationQueue) pop() []*Notification {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	items := q.items
+	q.items = nil
+	return items
+}
+
+func (w *WebSocket) fail(err error) {
+	if w.OnError != nil {
+		w.OnError(err)
+	}
+}
+
+// newMessageID returns a random UUID
+func newMessageID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,353 @@
+package ringcentral
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"golang.org/x/net/websocket"
+)
+
+// fakeGateway mimics the RingCentral WebSocket gateway. It drops the first
+// connection after one notification so the client has to recover the session.
+type fakeGateway struct {
+	mu            sync.Mutex
+	connections   int
+	subscriptions int
+	heartbeats    int
+	queries       []string
+}
+
+func gatewaySend(ws *websocket.Conn, parts ...interface{}) {
+	data, _ := json.Marshal(parts)
+	websocket.Message.Send(ws, string(data))
+}
+
+func (g *fakeGateway) serve(ws *websocket.Conn) {
+	g.mu.Lock()
+	g.connections++
+	conn := g.connections
+	g.queries = append(g.queries, ws.Request().URL.RawQuery)
+	g.mu.Unlock()
+
+	details := map[string]interface{}{"type": "ConnectionDetails", "wsc": map[string]interface{}{"token": "wsc-1", "sequence": 1}}
+	if ws.Request().URL.Query().Get("wsc") == "wsc-1" {
+		details["recoveryState"] = "Successful"
+	}
+	gatewaySend(ws, details, map[string]interface{}{})
+
+	notify := func(uuid string) {
+		gatewaySend(ws,
+			map[string]interface{}{"type": "ServerNotification", "wsc": map[string]interface{}{"token": "wsc-1", "sequence": 2}},
+			map[string]interface{}{"uuid": uuid, "event": "/restapi/v1.0/account/1/extension/2/presence", "body": map[string]interface{}{"extensionId": "2"}})
+	}
+	if conn > 1 {
+		notify("u-2")
+	}
+	for {
+		var data string
+		if err := websocket.Message.Receive(ws, &data); err != nil {
+			return
+		}
+		var parts []json.RawMessage
+		json.Unmarshal([]byte(data), &parts)
+		var h wsHeader
+		json.Unmarshal(parts[0], &h)
+		switch h.Type {
+		case "Heartbeat":
+			g.mu.Lock()
+			g.heartbeats++
+			g.mu.Unlock()
+			gatewaySend(ws, map[string]interface{}{"type": "Heartbeat", "messageId": h.MessageID})
+		case "ClientRequest":
+			g.mu.Lock()
+			g.subscriptions++
+			g.mu.Unlock()
+			var req CreateSubscriptionRequest
+			json.Unmarshal(parts[1], &req)
+			gatewaySend(ws,
+				map[string]interface{}{"type": "ClientRequest", "messageId": h.MessageID, "status": 200},
+				SubscriptionInfo{ID: "sub-1", Status: SubscriptionStatusActive, EventFilters: req.EventFilters, ExpirationTime: time.Now().Add(time.Hour), DeliveryMode: req.DeliveryMode})
+			notify("u-1")
+			if conn == 1 {
+				time.Sleep(50 * time.Millisecond)
+				ws.Close()
+				return
+			}
+		}
+	}
+}
+
+func TestWebSocket(t *testing.T) {
+	g := &fakeGateway{}
+	srv := httptest.NewServer(websocket.Handler(g.serve))
+	defer srv.Close()
+
+	received := make(chan string, 4)
+	d := NewDispatcher()
+	d.HandleExtensionPresence("", func(ctx context.Context, ev *ExtensionPresenceEvent) error {
+		received <- ev.UUID
+		return nil
+	})
+	filter := EventFilter{}.ExtensionPresence(0).String()
+	w := &WebSocket{
+		Handler:      d,
+		EventFilters: []string{filter},
+		Token: func(ctx context.Context) (*WebSocketToken, error) {
+			return &WebSocketToken{URI: "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws", AccessToken: "tok"}, nil
+		},
+		HeartbeatInterval: 20 * time.Millisecond,
+		ReconnectDelay:    10 * time.Millisecond,
+	}
+	var subscribed []*SubscriptionInfo
+	w.OnSubscribe = func(s *SubscriptionInfo) { subscribed = append(subscribed, s) }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.Run(ctx) }()
+
+	var uuids []string
+	for len(uuids) < 2 {
+		select {
+		case u := <-received:
+			uuids = append(uuids, u)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("received %v", uuids)
+		}
+	}
+	time.Sleep(60 * time.Millisecond)
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+
+	assert.Equal(t, []string{"u-1", "u-2"}, uuids)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	assert.Equal(t, 2, g.connections)
+	assert.Equal(t, 1, g.subscriptions, "the session should be recovered without subscribing again")
+	assert.True(t, g.heartbeats > 0)
+	assert.Equal(t, "access_token=tok", g.queries[0])
+	assert.Equal(t, "access_token=tok&wsc=wsc-1", g.queries[1])
+	if assert.Len(t, subscribed, 1) {
+		assert.Equal(t, TransportTypeWebSocket, subscribed[0].DeliveryMode.TransportType)
+		assert.Equal(t, []string{filter}, subscribed[0].EventFilters)
+	}
+}
+
+// scriptedGateway answers client requests with reply. It recovers sessions
+// and answers heartbeats; a reply status of 0 leaves a request unanswered.
+type scriptedGateway struct {
+	mu          sync.Mutex
+	connections int
+	requests    []string
+	reply       func(conn int, h wsHeader) (int, interface{})
+}
+
+func (g *scriptedGateway) serve(ws *websocket.Conn) {
+	g.mu.Lock()
+	g.connections++
+	conn := g.connections
+	g.mu.Unlock()
+
+	details := map[string]interface{}{"type": "ConnectionDetails", "wsc": map[string]interface{}{"token": "wsc-1", "sequence": 1}}
+	if ws.Request().URL.Query().Get("wsc") == "wsc-1" {
+		details["recoveryState"] = "Successful"
+	}
+	gatewaySend(ws, details, map[string]interface{}{})
+	for {
+		var data string
+		if err := websocket.Message.Receive(ws, &data); err != nil {
+			return
+		}
+		var parts []json.RawMessage
+		json.Unmarshal([]byte(data), &parts)
+		var h wsHeader
+		json.Unmarshal(parts[0], &h)
+		switch h.Type {
+		case "Heartbeat":
+			gatewaySend(ws, map[string]interface{}{"type": "Heartbeat", "messageId": h.MessageID})
+		case "ClientRequest":
+			g.mu.Lock()
+			g.requests = append(g.requests, fmt.Sprintf("%d %s", conn, h.Path))
+			g.mu.Unlock()
+			if status, body := g.reply(conn, h); status > 0 {
+				gatewaySend(ws, map[string]interface{}{"type": "ClientRequest", "messageId": h.MessageID, "status": status}, body)
+			}
+			if strings.HasSuffix(h.Path, "/subscription/") {
+				gatewaySend(ws,
+					map[string]interface{}{"type": "ServerNotification"},
+					map[string]interface{}{"uuid": fmt.Sprintf("u-%d", conn), "event": "/restapi/v1.0/account/1/extension/2/presence", "body": map[string]interface{}{}})
+			}
+		}
+	}
+}
+
+func (g *scriptedGateway) requestLog() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]string(nil), g.requests...)
+}
+
+// runScriptedGateway runs a WebSocket against g until cond is true, and
+// returns the errors reported by it
+func runScriptedGateway(t *testing.T, g *scriptedGateway, h NotificationHandler, cond func() bool) []string {
+	srv := httptest.NewServer(websocket.Handler(g.serve))
+	defer srv.Close()
+	var mu sync.Mutex
+	var errs []string
+	w := &WebSocket{
+		Handler: h,
+		Token: func(ctx context.Context) (*WebSocketToken, error) {
+			return &WebSocketToken{URI: "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws", AccessToken: "tok"}, nil
+		},
+		HeartbeatInterval: 20 * time.Millisecond,
+		ReconnectDelay:    10 * time.Millisecond,
+		OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err.Error())
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.Run(ctx) }()
+	assert.Eventually(t, cond, 2*time.Second, 5*time.Millisecond)
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+	mu.Lock()
+	defer mu.Unlock()
+	return errs
+}
+
+func testSubscription(expires time.Duration) SubscriptionInfo {
+	return SubscriptionInfo{ID: "sub-1", Status: SubscriptionStatusActive, ExpirationTime: time.Now().Add(expires)}
+}
+
+func TestWebSocketSubscribeRetry(t *testing.T) {
+	g := &scriptedGateway{reply: func(conn int, h wsHeader) (int, interface{}) {
+		switch conn {
+		case 1:
+			return 403, map[string]interface{}{"errorCode": "SUB-406"}
+		case 2:
+			return 0, nil
+		}
+		return 200, testSubscription(time.Hour)
+	}}
+	errs := runScriptedGateway(t, g, nil, func() bool { return len(g.requestLog()) == 3 })
+
+	// A failed and an unanswered subscription each drop the connection
+	assert.Equal(t, []string{"1 /restapi/v1.0/subscription/", "2 /restapi/v1.0/subscription/", "3 /restapi/v1.0/subscription/"}, g.requestLog())
+	if assert.True(t, len(errs) >= 2, "%v", errs) {
+		assert.Contains(t, errs[0], "status 403")
+		assert.Contains(t, errs[1], "request timeout")
+	}
+}
+
+func TestWebSocketRenewRetry(t *testing.T) {
+	renewals := 0
+	g := &scriptedGateway{reply: func(conn int, h wsHeader) (int, interface{}) {
+		if !strings.HasSuffix(h.Path, "/renew") {
+			return 200, testSubscription(time.Minute)
+		}
+		if renewals++; renewals == 1 {
+			return 503, map[string]interface{}{"errorCode": "CMN-211"}
+		}
+		return 200, testSubscription(time.Hour)
+	}}
+	runScriptedGateway(t, g, nil, func() bool { return len(g.requestLog()) == 3 })
+
+	// The failed renewal drops the connection; the recovered session renews again
+	assert.Equal(t, []string{
+		"1 /restapi/v1.0/subscription/",
+		"1 /restapi/v1.0/subscription/sub-1/renew",
+		"2 /restapi/v1.0/subscription/sub-1/renew",
+	}, g.requestLog())
+}
+
+func TestWebSocketSlowHandler(t *testing.T) {
+	g := &scriptedGateway{reply: func(conn int, h wsHeader) (int, interface{}) {
+		return 200, testSubscription(time.Hour)
+	}}
+	var handled int32
+	h := NotificationHandlerFunc(func(ctx context.Context, n *Notification) error {
+		time.Sleep(100 * time.Millisecond)
+		atomic.AddInt32(&handled, 1)
+		return nil
+	})
+	errs := runScriptedGateway(t, g, h, func() bool { return atomic.LoadInt32(&handled) == 1 })
+
+	// Handling took five heartbeat intervals without dropping the connection
+	assert.Empty(t, errs)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	assert.Equal(t, 1, g.connections)
+}
+
+func TestWebSocketStalledWrite(t *testing.T) {
+	var connections int32
+	stop := make(chan struct{})
+	defer close(stop)
+	// The gateway never reads, so a large enough request can't be written
+	srv := httptest.NewUnstartedServer(websocket.Handler(func(ws *websocket.Conn) {
+		atomic.AddInt32(&connections, 1)
+		gatewaySend(ws, map[string]interface{}{"type": "ConnectionDetails"}, map[string]interface{}{})
+		<-stop
+	}))
+	srv.Listener = smallBufferListener{srv.Listener}
+	srv.Start()
+	defer srv.Close()
+
+	var mu sync.Mutex
+	var errs []string
+	w := &WebSocket{
+		EventFilters: []string{strings.Repeat("x", 4<<20)},
+		Token: func(ctx context.Context) (*WebSocketToken, error) {
+			return &WebSocketToken{URI: "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws", AccessToken: "tok"}, nil
+		},
+		ReconnectDelay: 10 * time.Millisecond,
+		WriteTimeout:   50 * time.Millisecond,
+		OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err.Error())
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.Run(ctx) }()
+
+	// The stalled write doesn't block the rest of the client, and times out
+	// so the client reconnects
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&connections) == 1 }, 2*time.Second, time.Millisecond)
+	assert.Nil(t, w.Subscription())
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&connections) >= 2 }, 5*time.Second, 5*time.Millisecond)
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if assert.NotEmpty(t, errs) {
+		assert.Contains(t, errs[0], "timeout")
+	}
+}
+
+// smallBufferListener limits the receive buffer of accepted connections, so
+// writes to a peer which stops reading stall quickly
+type smallBufferListener struct {
+	net.Listener
+}
+
+func (l smallBufferListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if tc, ok := c.(*net.TCPConn); ok {
+		tc.SetReadBuffer(4096)
+	}
+	return c, err
+}